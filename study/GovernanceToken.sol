@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+// Optimism 的 GovernanceToken（OP），govtoken 绑定和 GovernanceToken_sol_GovernanceToken.abi/.bin 由本文件生成
+// 依赖 OpenZeppelin Contracts 4.7.3，使用 solc 0.8.15 编译，关闭 metadata 哈希（bytecode_hash = none）：
+//
+//	solc --base-path . --include-path node_modules --metadata-hash none --optimize --optimize-runs 999999 \
+//	  --abi --bin -o . --overwrite GovernanceToken.sol
+//	abigen --abi GovernanceToken_sol_GovernanceToken.abi --bin GovernanceToken_sol_GovernanceToken.bin \
+//	  --pkg govtoken --type GovernanceToken --out govtoken/govtoken.go
+import "@openzeppelin/contracts/access/Ownable.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/ERC20Burnable.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/draft-ERC20Permit.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/ERC20Votes.sol";
+
+/**
+ * @dev 支持 EIP-2612 permit 的 ERC20（ERC20Votes 继承自 ERC20Permit），EIP-712 域为 name="Optimism"、version="1"
+ * 部署后总量为 0，只有 owner（部署账户）可以 mint
+ */
+contract GovernanceToken is ERC20Burnable, ERC20Votes, Ownable {
+    constructor() ERC20("Optimism", "OP") ERC20Permit("Optimism") {}
+
+    /**
+     * @dev owner 给 _account 铸造 _amount 个代币
+     */
+    function mint(address _account, uint256 _amount) public onlyOwner {
+        _mint(_account, _amount);
+    }
+
+    function _afterTokenTransfer(
+        address from,
+        address to,
+        uint256 amount
+    ) internal override(ERC20, ERC20Votes) {
+        super._afterTokenTransfer(from, to, amount);
+    }
+
+    function _mint(address to, uint256 amount) internal override(ERC20, ERC20Votes) {
+        super._mint(to, amount);
+    }
+
+    function _burn(address account, uint256 amount) internal override(ERC20, ERC20Votes) {
+        super._burn(account, amount);
+    }
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import "./IERC721.sol";
+
+
+/**
+ * @dev 核心ERC1155接口（多代币标准，interfaceId 0xd9b67a26）
+ * 一个合约管理多种代币，每个id既可以是同质化代币也可以是NFT
+ */
+interface IERC1155 is IERC165 {
+    /**
+     * @dev 单个转移事件（铸造时from为0地址，销毁时to为0地址）
+     */
+    event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value);
+
+    /**
+     * @dev 批量转移事件，ids和values一一对应
+     */
+    event TransferBatch(
+        address indexed operator,
+        address indexed from,
+        address indexed to,
+        uint256[] ids,
+        uint256[] values
+    );
+
+    /**
+     * @dev 全部代币授权事件
+     */
+    event ApprovalForAll(address indexed account, address indexed operator, bool approved);
+
+    /**
+     * @dev id的元数据URI变化事件
+     */
+    event URI(string value, uint256 indexed id);
+
+    /**
+     * @dev 查询account持有的id代币数量
+     */
+    function balanceOf(address account, uint256 id) external view returns (uint256);
+
+    /**
+     * @dev 批量查询，accounts和ids一一对应
+     */
+    function balanceOfBatch(address[] calldata accounts, uint256[] calldata ids) external view returns (uint256[] memory);
+
+    /**
+     * @dev 授权或取消operator管理调用者的全部代币
+     */
+    function setApprovalForAll(address operator, bool approved) external;
+
+    /**
+     * @dev 查询operator是否可以管理account的全部代币
+     */
+    function isApprovedForAll(address account, address operator) external view returns (bool);
+
+    /**
+     * @dev 安全转移：to为合约时必须实现onERC1155Received并返回其选择器
+     */
+    function safeTransferFrom(address from, address to, uint256 id, uint256 value, bytes calldata data) external;
+
+    /**
+     * @dev 批量安全转移：to为合约时必须实现onERC1155BatchReceived并返回其选择器
+     */
+    function safeBatchTransferFrom(
+        address from,
+        address to,
+        uint256[] calldata ids,
+        uint256[] calldata values,
+        bytes calldata data
+    ) external;
+}
+
+
+/**
+ * @dev ERC1155元数据接口（继承自IERC1155，interfaceId 0x0e89341c）
+ * 返回的URI中的"{id}"由客户端替换为64位小写十六进制的id
+ */
+interface IERC1155MetadataURI is IERC1155 {
+    /**
+     * @dev 返回id的元数据URI
+     */
+    function uri(uint256 id) external view returns (string memory);
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+
+/**
+ * @dev EIP-2612 授权签名接口
+ * 持有人离线签署 EIP-712 格式的 Permit 消息，任何人都可以提交 permit 完成授权，持有人无需支付 gas
+ */
+interface IERC20Permit {
+    /**
+     * @dev 用owner的签名把owner授予spender的额度设置为value
+     * 签名中的nonce必须等于nonces(owner)，deadline之后签名失效
+     */
+    function permit(
+        address owner,
+        address spender,
+        uint256 value,
+        uint256 deadline,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) external;
+
+    /**
+     * @dev 查询owner的下一个permit签名应使用的nonce，每次成功调用permit后加一
+     */
+    function nonces(address owner) external view returns (uint256);
+
+    /**
+     * @dev 返回EIP-712域分隔符，由代币名称、版本、链ID和合约地址计算得出
+     */
+    // solhint-disable-next-line func-name-mixedcase
+    function DOMAIN_SEPARATOR() external view returns (bytes32);
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+
+/**
+ * @dev EIP-165 接口检测
+ * 合约声明自己实现了哪些接口，interfaceId 为接口中全部函数选择器的异或
+ */
+interface IERC165 {
+    /**
+     * @dev 合约实现了interfaceId对应的接口时返回true（0xffffffff必须返回false）
+     */
+    function supportsInterface(bytes4 interfaceId) external view returns (bool);
+}
+
+
+/**
+ * @dev 核心ERC721接口（非同质化代币，interfaceId 0x80ac58cd）
+ * 每个tokenId只有一个所有者，包含转移、授权、所有权查询等方法
+ */
+interface IERC721 is IERC165 {
+    /**
+     * @dev 转移事件（铸造时from为0地址，销毁时to为0地址）
+     */
+    event Transfer(address indexed from, address indexed to, uint256 indexed tokenId);
+
+    /**
+     * @dev 单个代币授权事件（approved为0地址表示取消授权）
+     */
+    event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId);
+
+    /**
+     * @dev 全部代币授权事件（owner授权或取消operator管理自己的全部代币）
+     */
+    event ApprovalForAll(address indexed owner, address indexed operator, bool approved);
+
+    /**
+     * @dev 查询owner持有的代币数量
+     */
+    function balanceOf(address owner) external view returns (uint256 balance);
+
+    /**
+     * @dev 查询tokenId的所有者（代币不存在时回滚）
+     */
+    function ownerOf(uint256 tokenId) external view returns (address owner);
+
+    /**
+     * @dev 安全转移：to为合约时必须实现onERC721Received并返回其选择器，data原样传给接收合约
+     */
+    function safeTransferFrom(address from, address to, uint256 tokenId, bytes calldata data) external;
+
+    /**
+     * @dev 安全转移（data为空）
+     */
+    function safeTransferFrom(address from, address to, uint256 tokenId) external;
+
+    /**
+     * @dev 转移：不检查to能否接收ERC721，转给合约可能导致代币永久锁定
+     */
+    function transferFrom(address from, address to, uint256 tokenId) external;
+
+    /**
+     * @dev 授权：允许to转移调用者的tokenId（每个代币同时只有一个被授权地址）
+     */
+    function approve(address to, uint256 tokenId) external;
+
+    /**
+     * @dev 授权或取消operator管理调用者的全部代币
+     */
+    function setApprovalForAll(address operator, bool approved) external;
+
+    /**
+     * @dev 查询tokenId的被授权地址
+     */
+    function getApproved(uint256 tokenId) external view returns (address operator);
+
+    /**
+     * @dev 查询operator是否可以管理owner的全部代币
+     */
+    function isApprovedForAll(address owner, address operator) external view returns (bool);
+}
+
+
+/**
+ * @dev ERC721元数据接口（继承自IERC721，interfaceId 0x5b5e139f）
+ * 包含集合名称、符号和每个代币的元数据URI
+ */
+interface IERC721Metadata is IERC721 {
+    /**
+     * @dev 返回集合名称
+     */
+    function name() external view returns (string memory);
+
+    /**
+     * @dev 返回集合符号
+     */
+    function symbol() external view returns (string memory);
+
+    /**
+     * @dev 返回tokenId的元数据URI（通常指向一个JSON文件）
+     */
+    function tokenURI(uint256 tokenId) external view returns (string memory);
+}
@@ -0,0 +1,317 @@
+package addressbook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"eth-client-study/study/decoder"
+	"eth-client-study/utils"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultPath 默认的地址簿文件，可用环境变量 ADDRESS_BOOK 指定其他文件
+const DefaultPath = "study/addressbook.json"
+
+// ErrUnknownName 地址簿中没有该名称
+var ErrUnknownName = errors.New("unknown address book name")
+
+// Kind 地址的类型
+type Kind string
+
+const (
+	KindEOA      Kind = "eoa"      // 外部账户
+	KindContract Kind = "contract" // 普通合约
+	KindToken    Kind = "token"    // 代币合约
+)
+
+// Entry 地址簿中的一项
+type Entry struct {
+	Name    string         `json:"name"` // 小写字母、数字、- 和 _，不区分大小写
+	Address common.Address `json:"address"`
+	Kind    Kind           `json:"kind"`
+	ABI     string         `json:"abi,omitempty"` // ABI 文件路径，相对于地址簿文件所在目录
+	Tags    []string       `json:"tags,omitempty"`
+	Note    string         `json:"note,omitempty"`
+}
+
+// Book 按链 ID 分组的地址簿
+type Book struct {
+	chains map[uint64]*Chain
+	path   string // 来源文件，用于错误信息
+}
+
+// Chain 一条链上的地址簿
+type Chain struct {
+	ID uint64
+
+	dir       string // ABI 路径的基准目录
+	source    string // 来源文件，用于错误信息
+	missing   bool   // 地址簿中没有这条链
+	entries   []Entry
+	byName    map[string]int
+	byAddress map[common.Address]int
+}
+
+func newChain(id uint64, dir string) *Chain {
+	return &Chain{ID: id, dir: dir, byName: make(map[string]int), byAddress: make(map[common.Address]int)}
+}
+
+// Read 解析地址簿 JSON：{"chains": {"<链 ID>": [Entry...]}}，dir 为 ABI 路径的基准目录
+// 地址按该链的校验和规则严格解析（见 utils.ParseAddressForChain），同一条链上名称或地址重复时返回错误
+func Read(r io.Reader, dir string) (*Book, error) {
+	var raw struct {
+		Chains map[string][]struct {
+			Entry
+			Address string `json:"address"`
+		} `json:"chains"`
+	}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	b := &Book{chains: make(map[uint64]*Chain)}
+	for key, entries := range raw.Chains {
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("chain %q: invalid chain ID", key)
+		}
+		c := newChain(id, dir)
+		for i, e := range entries {
+			entry := e.Entry
+			entry.Name = strings.ToLower(entry.Name)
+			if err := validName(entry.Name); err != nil {
+				return nil, fmt.Errorf("chain %d entry %d: %w", id, i, err)
+			}
+			if entry.Address, err = utils.ParseAddressForChain(e.Address, id); err != nil {
+				return nil, fmt.Errorf("chain %d %s: %w", id, entry.Name, err)
+			}
+			switch entry.Kind {
+			case KindEOA:
+				if entry.ABI != "" {
+					return nil, fmt.Errorf("chain %d %s: an EOA has no ABI", id, entry.Name)
+				}
+			case KindContract, KindToken:
+			default:
+				return nil, fmt.Errorf("chain %d %s: unknown kind %q", id, entry.Name, entry.Kind)
+			}
+			if j, ok := c.byName[entry.Name]; ok {
+				return nil, fmt.Errorf("chain %d: duplicate name %s (entries %d and %d)", id, entry.Name, j, i)
+			}
+			if j, ok := c.byAddress[entry.Address]; ok {
+				return nil, fmt.Errorf("chain %d: %s and %s have the same address %s", id, c.entries[j].Name, entry.Name, entry.Address.Hex())
+			}
+			c.byName[entry.Name] = len(c.entries)
+			c.byAddress[entry.Address] = len(c.entries)
+			c.entries = append(c.entries, entry)
+		}
+		b.chains[id] = c
+	}
+	return b, nil
+}
+
+// validName 名称不能为空、不能以 0x 开头（以免与地址混淆），只能包含小写字母、数字、- 和 _
+func validName(name string) error {
+	if name == "" {
+		return errors.New("empty name")
+	}
+	if strings.HasPrefix(name, "0x") {
+		return fmt.Errorf("name %q must not start with 0x", name)
+	}
+	for _, c := range name {
+		if !('a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+			return fmt.Errorf("name %q contains %q", name, c)
+		}
+	}
+	return nil
+}
+
+// Load 从文件读取地址簿
+func Load(path string) (*Book, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	b, err := Read(f, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	b.path = path
+	for _, c := range b.chains {
+		c.source = path
+	}
+	return b, nil
+}
+
+// LoadDefault 读取 ADDRESS_BOOK 指定的地址簿，未设置时读取 DefaultPath
+// DefaultPath 是相对于仓库根目录的路径，在其他目录运行时文件不存在，返回带路径的错误而不是空地址簿，
+// 以免脚本的默认名称（如 "store"、"bob"）被报告为未知名称
+func LoadDefault() (*Book, error) {
+	path := utils.GetEnvOrDefault("ADDRESS_BOOK", "")
+	if path != "" {
+		return Load(path)
+	}
+	b, err := Load(DefaultPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("default address book %s not found, run from the repository root or set ADDRESS_BOOK: %w", DefaultPath, err)
+	}
+	return b, err
+}
+
+// Chain 链 ID 对应的地址簿，地址簿中没有这条链时返回空的地址簿，按名称解析时报告该链不在地址簿中
+func (b *Book) Chain(id uint64) *Chain {
+	if c, ok := b.chains[id]; ok {
+		return c
+	}
+	c := newChain(id, "")
+	c.source, c.missing = b.path, true
+	return c
+}
+
+// ChainIDReader 能查询链 ID 的客户端，如 *ethclient.Client
+type ChainIDReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// MustOpen 供命令行脚本使用：读取默认地址簿并返回 client 所在链的部分，失败时退出
+func MustOpen(ctx context.Context, client ChainIDReader) *Chain {
+	b, err := LoadDefault()
+	if err != nil {
+		log.Fatal("读取地址簿失败：", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	return b.Chain(chainID.Uint64())
+}
+
+// MustOpenChain 供不连接节点的命令行脚本使用：读取默认地址簿并返回链 ID 为 id 的部分，失败时退出
+func MustOpenChain(id uint64) *Chain {
+	b, err := LoadDefault()
+	if err != nil {
+		log.Fatal("读取地址簿失败：", err)
+	}
+	return b.Chain(id)
+}
+
+// Entries 全部条目，按名称排序
+func (c *Chain) Entries() []Entry {
+	entries := append([]Entry(nil), c.entries...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// Lookup 按名称查找，不区分大小写
+func (c *Chain) Lookup(name string) (Entry, bool) {
+	i, ok := c.byName[strings.ToLower(name)]
+	if !ok {
+		return Entry{}, false
+	}
+	return c.entries[i], true
+}
+
+// Label 地址在地址簿中的名称
+func (c *Chain) Label(address common.Address) (string, bool) {
+	i, ok := c.byAddress[address]
+	if !ok {
+		return "", false
+	}
+	return c.entries[i].Name, true
+}
+
+// Annotate 带校验和的地址，地址簿中有该地址时附上名称，如 "0x183A...bC4 (store)"
+func (c *Chain) Annotate(address common.Address) string {
+	s := utils.ChecksumAddress(address, c.ID)
+	if name, ok := c.Label(address); ok {
+		return s + " (" + name + ")"
+	}
+	return s
+}
+
+// Resolve 解析名称或地址：以 0x 开头时按该链的校验和规则严格解析，否则在地址簿中查找名称
+func (c *Chain) Resolve(s string) (common.Address, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "0x") {
+		return utils.ParseAddressForChain(s, c.ID)
+	}
+	e, ok := c.Lookup(s)
+	if !ok {
+		if c.missing && c.source != "" {
+			return common.Address{}, fmt.Errorf("%w %q: %s has no entries for chain %d", ErrUnknownName, s, c.source, c.ID)
+		}
+		return common.Address{}, fmt.Errorf("%w %q on chain %d", ErrUnknownName, s, c.ID)
+	}
+	return e.Address, nil
+}
+
+// MustResolve 供命令行脚本解析 flag 和参数：无法解析时退出，直接输入的地址没有校验和时打印警告
+// name 为输入的来源，如 "-to"
+func (c *Chain) MustResolve(name, s string) common.Address {
+	address, err := c.Resolve(s)
+	if err != nil {
+		log.Fatalf("%s 不是有效的地址或地址簿名称：%v", name, err)
+	}
+	if strings.HasPrefix(strings.TrimSpace(s), "0x") && !utils.HasChecksum(s) {
+		log.Printf("警告：%s 的地址 %s 没有校验和，请核对是否为 %s", name, strings.TrimSpace(s), c.Annotate(address))
+	}
+	return address
+}
+
+// ReadABI 读取条目的 ABI JSON
+func (c *Chain) ReadABI(e Entry) (string, error) {
+	if e.ABI == "" {
+		return "", fmt.Errorf("%s has no ABI", e.Name)
+	}
+	path := e.ABI
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.dir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s ABI: %w", e.Name, err)
+	}
+	return string(data), nil
+}
+
+// NewDecoder 创建调用数据解析器：先使用地址簿中合约的 ABI，再回退到内置的 4 字节签名库，
+// 设置了 SIGNATURE_FILE 时签名库还包含该文件中的签名（见 decoder.SignatureDBFromEnv）
+func (c *Chain) NewDecoder() (*decoder.Decoder, error) {
+	db, err := decoder.SignatureDBFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	d := decoder.NewDecoder(db)
+	if err := c.RegisterABIs(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// RegisterABIs 把地址簿中所有带 ABI 的合约注册到解析器，解析调用数据时优先使用
+func (c *Chain) RegisterABIs(d *decoder.Decoder) error {
+	for _, e := range c.entries {
+		if e.ABI == "" {
+			continue
+		}
+		abiJSON, err := c.ReadABI(e)
+		if err != nil {
+			return err
+		}
+		if err := d.RegisterABIJSON(e.Address, abiJSON); err != nil {
+			return fmt.Errorf("%s ABI: %w", e.Name, err)
+		}
+	}
+	return nil
+}
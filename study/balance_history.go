@@ -0,0 +1,135 @@
+// balance_history.go - 历史余额时间序列
+// 按区块间隔或时间间隔采样 ETH / ERC20 余额，也可二分定位余额变化的精确区块，输出 CSV 或 JSON
+// 用法：
+//
+//	go run study/balance_history.go -from 5500000 -to 5600000 -step 10000
+//	go run study/balance_history.go -token mkt -start 2025-01-01T00:00:00Z -end 2025-02-01T00:00:00Z -interval 24h
+//	go run study/balance_history.go -from 5500000 -to 5600000 -changes -format json -out history.json
+//	go run study/balance_history.go -at finalized
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/balances"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/blocktime"
+	token "eth-client-study/study/erc20"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	accountFlag := flag.String("account", "alice", "要查询的账户地址或地址簿名称")
+	tokenFlag := flag.String("token", "", "ERC20 代币地址或地址簿名称，为空时查询 ETH 余额")
+	fromFlag := flag.Uint64("from", 5500000, "起始区块")
+	toFlag := flag.Uint64("to", 5600000, "结束区块")
+	stepFlag := flag.Uint64("step", 10000, "采样间隔（区块数）")
+	startFlag := flag.String("start", "", "按时间采样的起始时间（RFC3339），设置后忽略 -from/-to/-step")
+	endFlag := flag.String("end", "", "按时间采样的结束时间（RFC3339）")
+	intervalFlag := flag.Duration("interval", 24*time.Hour, "按时间采样的间隔")
+	changesFlag := flag.Bool("changes", false, "二分查找 [from, to] 内余额变化的精确区块")
+	atFlag := flag.String("at", "", "只输出该区块（latest/safe/finalized/区块号/区块哈希）的余额")
+	formatFlag := flag.String("format", "csv", "输出格式：csv、json")
+	outFlag := flag.String("out", "", "输出文件，默认输出到标准输出")
+	flag.Parse()
+
+	// 历史状态查询需要归档节点
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	book := addressbook.MustOpen(ctx, client)
+
+	account := book.MustResolve("-account", *accountFlag)
+	balanceFn := balances.ETHBalance(client, account)
+	decimals := uint8(18)
+	if *tokenFlag != "" {
+		tokenAddress := book.MustResolve("-token", *tokenFlag)
+		if balanceFn, err = balances.TokenBalance(client, tokenAddress, account); err != nil {
+			log.Fatal(err)
+		}
+		instance, err := token.NewErc20Caller(tokenAddress, client)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if decimals, err = instance.Decimals(&bind.CallOpts{Context: ctx}); err != nil {
+			log.Fatal("获取代币精度失败：", err)
+		}
+	}
+	tracker := balances.NewTracker(client, balanceFn)
+	head, err := blockref.DefaultFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var points []balances.Point
+	switch {
+	case *atFlag != "":
+		sel, err := blockref.Parse(*atFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		point, err := tracker.At(ctx, sel)
+		if err != nil {
+			log.Fatal(err)
+		}
+		points = []balances.Point{point}
+	case *startFlag != "":
+		start, err := time.Parse(time.RFC3339, *startFlag)
+		if err != nil {
+			log.Fatal("时间格式错误：", err)
+		}
+		end := time.Now()
+		if *endFlag != "" {
+			if end, err = time.Parse(time.RFC3339, *endFlag); err != nil {
+				log.Fatal("时间格式错误：", err)
+			}
+		}
+		var times []time.Time
+		for at := start; !at.After(end); at = at.Add(*intervalFlag) {
+			times = append(times, at)
+		}
+		// 只在 BLOCK_TAG 对应的区块（如 finalized）及之前采样，晚于它的时间点取该区块
+		finder := blocktime.NewFinder(client)
+		finder.Head = head
+		points, err = tracker.SampleTimes(ctx, finder, times)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case *changesFlag:
+		if points, err = tracker.ChangePoints(ctx, *fromFlag, *toFlag); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		if points, err = tracker.SampleEvery(ctx, *fromFlag, *toFlag, *stepFlag); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if *outFlag != "" {
+		f, err := os.Create(*outFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if *formatFlag == "json" {
+		err = balances.WriteJSON(out, points, decimals)
+	} else {
+		err = balances.WriteCSV(out, points, decimals)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,218 @@
+package balances
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/blocktime"
+	token "eth-client-study/study/erc20"
+	"eth-client-study/utils"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BalanceFunc 返回 sel 对应区块时的余额
+type BalanceFunc func(ctx context.Context, sel blockref.Selector) (*big.Int, error)
+
+// ETHBalance 返回账户 ETH 余额的查询函数
+func ETHBalance(reader ethereum.ChainStateReader, account common.Address) BalanceFunc {
+	return func(ctx context.Context, sel blockref.Selector) (*big.Int, error) {
+		return blockref.BalanceAt(ctx, reader, account, sel)
+	}
+}
+
+// TokenBalance 返回账户 ERC20 余额的查询函数，通过 blockref.Bind 读取历史状态
+func TokenBalance(caller bind.ContractCaller, tokenAddress, account common.Address) (BalanceFunc, error) {
+	if _, err := token.NewErc20Caller(tokenAddress, caller); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, sel blockref.Selector) (*big.Int, error) {
+		instance, err := token.NewErc20Caller(tokenAddress, blockref.Bind(caller, sel))
+		if err != nil {
+			return nil, err
+		}
+		return instance.BalanceOf(&bind.CallOpts{Context: ctx}, account)
+	}, nil
+}
+
+// Point 余额时间序列中的一个采样点
+type Point struct {
+	Block   uint64   `json:"block"`
+	Time    uint64   `json:"timestamp"`
+	Balance *big.Int `json:"balance"`
+}
+
+// Tracker 历史余额采样器
+// 需要连接归档节点，普通全节点通常只保留最近 128 个区块的状态
+type Tracker struct {
+	headers blocktime.HeaderReader
+	balance BalanceFunc
+}
+
+// NewTracker 创建采样器，headers 用于给每个采样点补充区块时间
+func NewTracker(headers blocktime.HeaderReader, balance BalanceFunc) *Tracker {
+	return &Tracker{headers: headers, balance: balance}
+}
+
+// SampleEvery 在 [from, to] 区间内每隔 step 个区块采样一次，区间终点总会被采样
+func (t *Tracker) SampleEvery(ctx context.Context, from, to, step uint64) ([]Point, error) {
+	if step == 0 {
+		return nil, fmt.Errorf("step must be positive")
+	}
+	if to < from {
+		return nil, fmt.Errorf("invalid range [%d, %d]", from, to)
+	}
+	var blocks []uint64
+	for n := from; n < to; n += step {
+		blocks = append(blocks, n)
+	}
+	return t.SampleBlocks(ctx, append(blocks, to))
+}
+
+// SampleTimes 在指定的时间点采样，每个时间点取当时的最新区块
+func (t *Tracker) SampleTimes(ctx context.Context, finder *blocktime.Finder, times []time.Time) ([]Point, error) {
+	blocks := make([]uint64, len(times))
+	for i, at := range times {
+		block, err := finder.BlockAtOrBefore(ctx, at)
+		if err != nil {
+			return nil, fmt.Errorf("block at %s: %w", at.UTC().Format(time.RFC3339), err)
+		}
+		blocks[i] = block
+	}
+	return t.SampleBlocks(ctx, blocks)
+}
+
+// SampleBlocks 在给定区块上采样
+func (t *Tracker) SampleBlocks(ctx context.Context, blocks []uint64) ([]Point, error) {
+	points := make([]Point, 0, len(blocks))
+	for _, block := range blocks {
+		point, err := t.point(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// ChangePoints 用二分法找出 [from, to] 内余额发生变化的所有区块
+// 返回的第一个点是 from 处的余额，之后每个点是新余额首次出现的区块。
+// 若某个子区间两端余额相同则认为其中没有变化，因此区间内先增后减回原值的情况会被跳过
+func (t *Tracker) ChangePoints(ctx context.Context, from, to uint64) ([]Point, error) {
+	if to < from {
+		return nil, fmt.Errorf("invalid range [%d, %d]", from, to)
+	}
+	start, err := t.point(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := t.point(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	points := []Point{start}
+	if from == to {
+		return points, nil
+	}
+	changes, err := t.bisect(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return append(points, changes...), nil
+}
+
+// bisect 返回 (lo, hi] 区间内的变化点，lo 和 hi 的余额已知
+func (t *Tracker) bisect(ctx context.Context, lo, hi Point) ([]Point, error) {
+	if lo.Balance.Cmp(hi.Balance) == 0 {
+		return nil, nil
+	}
+	if hi.Block-lo.Block == 1 {
+		return []Point{hi}, nil
+	}
+	mid, err := t.point(ctx, lo.Block+(hi.Block-lo.Block)/2)
+	if err != nil {
+		return nil, err
+	}
+	left, err := t.bisect(ctx, lo, mid)
+	if err != nil {
+		return nil, err
+	}
+	right, err := t.bisect(ctx, mid, hi)
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+// At 在 sel 对应的区块（如 finalized）上采样，需要 NewTracker 时传入 headers 以确定区块号
+func (t *Tracker) At(ctx context.Context, sel blockref.Selector) (Point, error) {
+	if t.headers == nil {
+		return Point{}, fmt.Errorf("tracker has no header reader")
+	}
+	header, err := blockref.Header(ctx, t.headers, sel)
+	if err != nil {
+		return Point{}, err
+	}
+	return t.point(ctx, header.Number.Uint64())
+}
+
+func (t *Tracker) point(ctx context.Context, block uint64) (Point, error) {
+	number := new(big.Int).SetUint64(block)
+	balance, err := t.balance(ctx, blockref.Number(block))
+	if err != nil {
+		return Point{}, fmt.Errorf("balance at block %d: %w", block, err)
+	}
+	point := Point{Block: block, Balance: balance}
+	if t.headers != nil {
+		header, err := t.headers.HeaderByNumber(ctx, number)
+		if err != nil {
+			return Point{}, fmt.Errorf("header %d: %w", block, err)
+		}
+		point.Time = header.Time
+	}
+	return point, nil
+}
+
+// WriteCSV 输出 CSV，amount 列为按 decimals 格式化后的金额，便于直接画图
+func WriteCSV(w io.Writer, points []Point, decimals uint8) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"block", "timestamp", "time", "balance", "amount"}); err != nil {
+		return err
+	}
+	for _, p := range points {
+		if err := cw.Write([]string{
+			strconv.FormatUint(p.Block, 10),
+			strconv.FormatUint(p.Time, 10),
+			time.Unix(int64(p.Time), 0).UTC().Format(time.RFC3339),
+			p.Balance.String(),
+			utils.FormatUnits(p.Balance, decimals),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON 输出 JSON 数组
+func WriteJSON(w io.Writer, points []Point, decimals uint8) error {
+	type jsonPoint struct {
+		Point
+		Amount string `json:"amount"`
+	}
+	out := make([]jsonPoint, len(points))
+	for i, p := range points {
+		out[i] = jsonPoint{Point: p, Amount: utils.FormatUnits(p.Balance, decimals)}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,130 @@
+// batch_payout.go - 按 CSV 批量发放 ETH 和 ERC20
+// CSV 每行为 recipient,amount[,token]，token 为空表示 ETH；金额按资产精度解析
+// 发送前检查全部地址和总余额，进度写入状态文件，中途崩溃后用相同参数重新运行即可继续，不会重复付款
+// 广播后超过 -max-wait 仍未上链的付款标记为 stuck；用 -replace 重新运行时以同一 nonce 和更高手续费替换这些交易，
+// 新交易哈希写入状态文件，新旧交易最多只有一笔上链
+// 用法：
+//
+//	go run study/batch_payout.go -csv study/payouts_example.csv -check-only
+//	go run study/batch_payout.go -csv study/payouts_example.csv -confirmations 3
+//	go run study/batch_payout.go -csv study/payouts_example.csv -replace
+package main
+
+import (
+	"context"
+	"eth-client-study/study/payout"
+	"eth-client-study/utils"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	csvFlag := flag.String("csv", "study/payouts_example.csv", "付款 CSV 文件")
+	stateFlag := flag.String("state", "", "状态文件，默认为 <csv>.state.json")
+	resultsFlag := flag.String("results", "", "结果 CSV，默认为 <csv>.results.csv")
+	confirmationsFlag := flag.Uint64("confirmations", 1, "等待的确认数")
+	checkOnlyFlag := flag.Bool("check-only", false, "只检查 CSV 和余额，不发送交易")
+	maxWaitFlag := flag.Duration("max-wait", 10*time.Minute, "交易广播后等待上链的最长时间，超时标记为 stuck，0 表示一直等待")
+	replaceFlag := flag.Bool("replace", false, "用同一 nonce 和更高的手续费重新发送 stuck 的付款（replace-by-fee）")
+	flag.Parse()
+	base := strings.TrimSuffix(*csvFlag, ".csv")
+	if *stateFlag == "" {
+		*stateFlag = base + ".state.json"
+	}
+	if *resultsFlag == "" {
+		*resultsFlag = base + ".results.csv"
+	}
+
+	rows, err := payout.LoadCSV(*csvFlag)
+	if err != nil {
+		log.Fatal("CSV 格式错误：\n", err)
+	}
+	fmt.Printf("读取 %d 笔付款\n", len(rows))
+	for _, row := range rows {
+		if row.NoChecksum {
+			log.Printf("警告：第 %d 行的地址没有校验和，请核对收款人 %s", row.Line, row.Recipient.Hex())
+		}
+	}
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	//账户私钥
+	privateKey, err := crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1"))
+	if err != nil {
+		log.Fatal("privateKey err:", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts.Context = ctx
+
+	state, err := payout.OpenState(*stateFlag, opts.From, chainID)
+	if err != nil {
+		log.Fatal("读取状态文件失败：", err)
+	}
+	payer, err := payout.NewPayer(client, opts, state)
+	if err != nil {
+		log.Fatal(err)
+	}
+	payer.Confirmations = *confirmationsFlag
+	payer.MaxWait = *maxWaitFlag
+	if err := payer.Prepare(ctx, rows); err != nil {
+		log.Fatal("付款数据错误：\n", err)
+	}
+	counts := state.Count()
+	fmt.Printf("发送方 %s，状态文件 %s：待发送 %d，已签名 %d，已发送 %d，未上链 %d，已确认 %d，失败 %d\n",
+		opts.From.Hex(), state.Path(), counts[payout.StatusPending], counts[payout.StatusSigned], counts[payout.StatusSent], counts[payout.StatusStuck], counts[payout.StatusConfirmed], counts[payout.StatusFailed])
+	if err := payer.Check(ctx); err != nil {
+		log.Fatal("余额检查失败：\n", err)
+	}
+	fmt.Println("余额检查通过")
+	if *checkOnlyFlag {
+		return
+	}
+
+	var sendErr error
+	if *replaceFlag {
+		n, err := payer.Replace(ctx)
+		if n > 0 {
+			fmt.Printf("已用更高的手续费替换 %d 笔未上链的交易\n", n)
+		}
+		sendErr = err
+	}
+	if sendErr == nil {
+		sendErr = payer.Send(ctx)
+	}
+	if sendErr != nil {
+		log.Println("发送中断：", sendErr)
+	} else if err := payer.Wait(ctx); err != nil {
+		log.Println("等待确认中断：", err)
+	}
+	if err := payout.SaveResults(*resultsFlag, state.Entries); err != nil {
+		log.Fatal("写入结果失败：", err)
+	}
+	counts = state.Count()
+	fmt.Printf("已确认 %d，失败 %d，未完成 %d，结果已写入 %s\n", counts[payout.StatusConfirmed], counts[payout.StatusFailed],
+		counts[payout.StatusPending]+counts[payout.StatusSigned]+counts[payout.StatusSent]+counts[payout.StatusStuck], *resultsFlag)
+	if counts[payout.StatusStuck] > 0 {
+		fmt.Printf("%d 笔交易超过 %s 未上链，可加 -replace 重新运行以提高手续费替换\n", counts[payout.StatusStuck], *maxWaitFlag)
+	}
+}
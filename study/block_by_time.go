@@ -0,0 +1,73 @@
+// block_by_time.go - 按时间查找区块
+// 用法：
+//
+//	go run study/block_by_time.go -time 2025-01-01T00:00:00Z
+//	go run study/block_by_time.go -time 2025-03-01T00:00:00Z -end 2025-03-31T23:59:59Z
+package main
+
+import (
+	"context"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/blocktime"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	timeFlag := flag.String("time", "2025-01-01T00:00:00Z", "目标时间（RFC3339 格式）")
+	endFlag := flag.String("end", "", "可选的结束时间，指定后输出整个时间区间对应的区块范围")
+	flag.Parse()
+
+	at, err := time.Parse(time.RFC3339, *timeFlag)
+	if err != nil {
+		log.Fatal("时间格式错误：", err)
+	}
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	finder := blocktime.NewFinder(client)
+	// 查找范围的上界取 BLOCK_TAG，例如 finalized 时只返回已最终确定的区块
+	if finder.Head, err = blockref.DefaultFromEnv(); err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if *endFlag != "" {
+		end, err := time.Parse(time.RFC3339, *endFlag)
+		if err != nil {
+			log.Fatal("时间格式错误：", err)
+		}
+		start, stop, err := finder.BlockRange(ctx, at, end)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("时间区间 %s ~ %s 对应区块：%d ~ %d\n", at.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339), start, stop)
+		return
+	}
+
+	after, err := finder.BlockAtOrAfter(ctx, at)
+	if err != nil {
+		log.Fatal(err)
+	}
+	before, err := finder.BlockAtOrBefore(ctx, at)
+	if err != nil {
+		log.Fatal(err)
+	}
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(after))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("目标时间：%s\n", at.UTC().Format(time.RFC3339))
+	fmt.Printf("该时间及之后的第一个区块：%d（%s）\n", after, time.Unix(int64(header.Time), 0).UTC().Format(time.RFC3339))
+	// 查询“某时刻的余额”时应使用该区块号
+	fmt.Printf("该时间点的最新区块：%d\n", before)
+}
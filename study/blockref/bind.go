@@ -0,0 +1,165 @@
+package blockref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrCanonicalUnsupported 数据源无法核对区块是否在主链上，requireCanonical 的哈希选择器只能通过 Reader 或 *ethclient.Client 使用
+var ErrCanonicalUnsupported = errors.New("backend cannot enforce requireCanonical")
+
+// BigInt 转换为 bind.CallOpts.BlockNumber 风格的参数：latest 及零值为 nil，其他标签为 rpc.BlockNumber 的负数
+// （ethclient 会把负数编码为对应的标签名），按哈希选择时 ok 为 false
+func (s Selector) BigInt() (*big.Int, bool) {
+	if s.hash != nil {
+		return nil, false
+	}
+	if s.number == nil || *s.number == rpc.LatestBlockNumber {
+		return nil, true
+	}
+	return big.NewInt(s.number.Int64()), true
+}
+
+// CallOpts 转换为 abigen 绑定方法使用的 CallOpts；按哈希选择时 requireCanonical 不生效，需要时改用 Reader.Caller
+func (s Selector) CallOpts(ctx context.Context) *bind.CallOpts {
+	opts := &bind.CallOpts{Context: ctx}
+	switch {
+	case s.hash != nil:
+		opts.BlockHash = *s.hash
+	case s.number != nil && *s.number == rpc.PendingBlockNumber:
+		opts.Pending = true
+	default:
+		opts.BlockNumber, _ = s.BigInt()
+	}
+	return opts
+}
+
+// Bind 返回固定在 sel 上读取的 bind.ContractCaller，调用时 blockNumber 不为 nil 则以其为准
+// caller 为 *ethclient.Client 时等同于 Reader.Caller；其他数据源（如模拟链）按哈希选择时需要实现 bind.BlockHashContractCaller，
+// 且不支持 requireCanonical。sel 为零值时原样返回 caller
+func Bind(caller bind.ContractCaller, sel Selector) bind.ContractCaller {
+	if sel.IsZero() {
+		return caller
+	}
+	if client, ok := caller.(*ethclient.Client); ok {
+		return NewReader(client.Client()).Caller(sel)
+	}
+	return &boundCaller{caller: caller, sel: sel}
+}
+
+type boundCaller struct {
+	caller bind.ContractCaller
+	sel    Selector
+}
+
+func (c *boundCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if number, ok := c.sel.BigInt(); ok || blockNumber != nil {
+		return c.caller.CodeAt(ctx, contract, firstNonNil(blockNumber, number))
+	}
+	hashCaller, err := c.hashCaller()
+	if err != nil {
+		return nil, err
+	}
+	return hashCaller.CodeAtHash(ctx, contract, *c.sel.hash)
+}
+
+func (c *boundCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if number, ok := c.sel.BigInt(); ok || blockNumber != nil {
+		return c.caller.CallContract(ctx, call, firstNonNil(blockNumber, number))
+	}
+	hashCaller, err := c.hashCaller()
+	if err != nil {
+		return nil, err
+	}
+	return hashCaller.CallContractAtHash(ctx, call, *c.sel.hash)
+}
+
+func (c *boundCaller) hashCaller() (bind.BlockHashContractCaller, error) {
+	if c.sel.requireCanonical {
+		return nil, fmt.Errorf("%w: %s", ErrCanonicalUnsupported, c.sel)
+	}
+	hashCaller, ok := c.caller.(bind.BlockHashContractCaller)
+	if !ok {
+		return nil, bind.ErrNoBlockHashState
+	}
+	return hashCaller, nil
+}
+
+func firstNonNil(a, b *big.Int) *big.Int {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// HeaderByNumberReader 按区块号读取区块头，*ethclient.Client 满足该接口
+type HeaderByNumberReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type headerByHashReader interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// Header 读取 sel 对应的区块头，零值为 latest
+// 按哈希选择时 reader 还需要实现 HeaderByHash，requireCanonical 时会按区块号核对是否仍在主链上
+func Header(ctx context.Context, reader HeaderByNumberReader, sel Selector) (*types.Header, error) {
+	if number, ok := sel.BigInt(); ok {
+		header, err := reader.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("block %s: %w", sel, err)
+		}
+		return header, nil
+	}
+	byHash, ok := reader.(headerByHashReader)
+	if !ok {
+		return nil, fmt.Errorf("block %s: header reader cannot look up blocks by hash", sel)
+	}
+	hash := *sel.hash
+	header, err := byHash.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("block %s: %w", sel, err)
+	}
+	if sel.requireCanonical {
+		canonical, err := reader.HeaderByNumber(ctx, header.Number)
+		if err != nil {
+			return nil, err
+		}
+		if canonical.Hash() != hash {
+			return nil, fmt.Errorf("%w: %s at height %d", ErrNotCanonical, hash.Hex(), header.Number.Uint64())
+		}
+	}
+	return header, nil
+}
+
+type balanceByHashReader interface {
+	BalanceAtHash(ctx context.Context, account common.Address, blockHash common.Hash) (*big.Int, error)
+}
+
+// BalanceAt 在 sel 对应的区块上查询 ETH 余额，reader 为 *ethclient.Client 时等同于 Reader.BalanceAt
+// 其他数据源按哈希选择时需要实现 BalanceAtHash，且不支持 requireCanonical
+func BalanceAt(ctx context.Context, reader ethereum.ChainStateReader, account common.Address, sel Selector) (*big.Int, error) {
+	if client, ok := reader.(*ethclient.Client); ok {
+		return NewReader(client.Client()).BalanceAt(ctx, account, sel)
+	}
+	if number, ok := sel.BigInt(); ok {
+		return reader.BalanceAt(ctx, account, number)
+	}
+	if sel.requireCanonical {
+		return nil, fmt.Errorf("%w: %s", ErrCanonicalUnsupported, sel)
+	}
+	byHash, ok := reader.(balanceByHashReader)
+	if !ok {
+		return nil, fmt.Errorf("block %s: state reader cannot look up blocks by hash", sel)
+	}
+	return byHash.BalanceAtHash(ctx, account, *sel.hash)
+}
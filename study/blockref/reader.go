@@ -0,0 +1,207 @@
+package blockref
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/utils"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// EnvDefault 配置默认选择器的环境变量名（可写在 .env 中），例如 BLOCK_TAG=finalized
+const EnvDefault = "BLOCK_TAG"
+
+// ErrNotCanonical 按哈希选择且 requireCanonical 时，该区块已不在主链上
+var ErrNotCanonical = errors.New("block is not canonical")
+
+// DefaultFromEnv 读取 BLOCK_TAG 配置的默认选择器，未配置时返回 Latest
+func DefaultFromEnv() (Selector, error) {
+	sel, err := Parse(utils.GetEnvOrDefault(EnvDefault, "latest"))
+	if err != nil {
+		return Selector{}, fmt.Errorf("%s: %w", EnvDefault, err)
+	}
+	return sel, nil
+}
+
+// ParseOrDefault 解析命令行参数中的选择器，为空时使用 BLOCK_TAG 配置的默认值
+func ParseOrDefault(s string) (Selector, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultFromEnv()
+	}
+	return Parse(s)
+}
+
+// Reader 所有读接口都接受 Selector 参数，传零值时使用 Default
+// 对账等场景可把 Default 设为 Finalized，保证读到的状态不会因重组而改变
+type Reader struct {
+	client *rpc.Client
+	eth    *ethclient.Client
+
+	Default Selector
+}
+
+// NewReader 创建读取器，默认选择器为 Latest
+func NewReader(client *rpc.Client) *Reader {
+	return &Reader{client: client, eth: ethclient.NewClient(client), Default: Latest}
+}
+
+func (r *Reader) resolve(sel Selector) Selector {
+	if sel.IsZero() {
+		if r.Default.IsZero() {
+			return Latest
+		}
+		return r.Default
+	}
+	return sel
+}
+
+// BalanceAt 查询账户 ETH 余额
+func (r *Reader) BalanceAt(ctx context.Context, account common.Address, sel Selector) (*big.Int, error) {
+	var result hexutil.Big
+	if err := r.client.CallContext(ctx, &result, "eth_getBalance", account, r.resolve(sel)); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// NonceAt 查询账户 nonce
+func (r *Reader) NonceAt(ctx context.Context, account common.Address, sel Selector) (uint64, error) {
+	var result hexutil.Uint64
+	err := r.client.CallContext(ctx, &result, "eth_getTransactionCount", account, r.resolve(sel))
+	return uint64(result), err
+}
+
+// CodeAt 查询合约代码
+func (r *Reader) CodeAt(ctx context.Context, account common.Address, sel Selector) ([]byte, error) {
+	var result hexutil.Bytes
+	err := r.client.CallContext(ctx, &result, "eth_getCode", account, r.resolve(sel))
+	return result, err
+}
+
+// StorageAt 查询存储槽
+func (r *Reader) StorageAt(ctx context.Context, account common.Address, slot common.Hash, sel Selector) ([]byte, error) {
+	var result hexutil.Bytes
+	err := r.client.CallContext(ctx, &result, "eth_getStorageAt", account, slot, r.resolve(sel))
+	return result, err
+}
+
+// CallContract 在选定区块的状态上执行只读调用
+func (r *Reader) CallContract(ctx context.Context, msg ethereum.CallMsg, sel Selector) ([]byte, error) {
+	var result hexutil.Bytes
+	err := r.client.CallContext(ctx, &result, "eth_call", toCallArg(msg), r.resolve(sel))
+	return result, err
+}
+
+// HeaderAt 查询区块头；按哈希且 requireCanonical 时会再按区块号核对是否仍在主链上
+func (r *Reader) HeaderAt(ctx context.Context, sel Selector) (*types.Header, error) {
+	sel = r.resolve(sel)
+	if hash, ok := sel.BlockHash(); ok {
+		header, err := r.eth.HeaderByHash(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if sel.requireCanonical {
+			canonical, err := r.eth.HeaderByNumber(ctx, header.Number)
+			if err != nil {
+				return nil, err
+			}
+			if canonical.Hash() != hash {
+				return nil, fmt.Errorf("%w: %s at height %d", ErrNotCanonical, hash.Hex(), header.Number.Uint64())
+			}
+		}
+		return header, nil
+	}
+	var header *types.Header
+	if err := r.client.CallContext(ctx, &header, "eth_getBlockByNumber", sel, false); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		// 节点尚未产生 safe/finalized 区块（例如刚启动的开发链）时也会返回 null
+		return nil, fmt.Errorf("block %s: %w", sel, ethereum.NotFound)
+	}
+	return header, nil
+}
+
+// FilterLogs 查询日志，结束区块不超过 sel 对应的区块
+// 先把 sel 解析为具体区块号再查询，避免 from/to 两次解析标签时链头已前进；按 BlockHash 过滤时不受 sel 影响
+func (r *Reader) FilterLogs(ctx context.Context, q ethereum.FilterQuery, sel Selector) ([]types.Log, error) {
+	if q.BlockHash != nil {
+		return r.eth.FilterLogs(ctx, q)
+	}
+	header, err := r.HeaderAt(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+	if q.ToBlock == nil || q.ToBlock.Cmp(header.Number) > 0 {
+		q.ToBlock = new(big.Int).Set(header.Number)
+	}
+	if q.FromBlock != nil && q.FromBlock.Cmp(q.ToBlock) > 0 {
+		return nil, nil
+	}
+	return r.eth.FilterLogs(ctx, q)
+}
+
+// Caller 返回固定在 sel 上读取的 bind.ContractCaller，可直接传给 abigen 生成的 NewXxxCaller
+// 调用时 CallOpts.BlockNumber 不为 nil 则以其为准
+func (r *Reader) Caller(sel Selector) bind.ContractCaller {
+	return &caller{reader: r, sel: sel}
+}
+
+type caller struct {
+	reader *Reader
+	sel    Selector
+}
+
+func (c *caller) selector(blockNumber *big.Int) Selector {
+	if blockNumber != nil {
+		return FromBigInt(blockNumber)
+	}
+	return c.sel
+}
+
+func (c *caller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.reader.CodeAt(ctx, contract, c.selector(blockNumber))
+}
+
+func (c *caller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.reader.CallContract(ctx, call, c.selector(blockNumber))
+}
+
+// toCallArg 与 ethclient 中的同名函数一致
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
+	return arg
+}
@@ -0,0 +1,166 @@
+package blockref
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Selector 指定读取哪个区块的状态，对应 JSON-RPC 的区块参数（EIP-1898）
+// 零值表示未指定，由 Reader 替换为配置的默认值
+type Selector struct {
+	number           *rpc.BlockNumber
+	hash             *common.Hash
+	requireCanonical bool
+}
+
+var (
+	Latest    = tag(rpc.LatestBlockNumber)    // 最新区块，可能被重组
+	Pending   = tag(rpc.PendingBlockNumber)   // 待打包状态
+	Safe      = tag(rpc.SafeBlockNumber)      // 已获得多数验证者投票、一般不会被重组的区块
+	Finalized = tag(rpc.FinalizedBlockNumber) // 已最终确定的区块，回滚需要销毁至少 1/3 的质押
+	Earliest  = tag(rpc.EarliestBlockNumber)  // 创世区块
+)
+
+func tag(n rpc.BlockNumber) Selector {
+	return Selector{number: &n}
+}
+
+// Number 按区块号选择
+func Number(n uint64) Selector {
+	return tag(rpc.BlockNumber(n))
+}
+
+// Hash 按区块哈希选择，requireCanonical 为 true 时要求该区块在主链上，已被重组掉的区块会报错
+func Hash(hash common.Hash, requireCanonical bool) Selector {
+	return Selector{hash: &hash, requireCanonical: requireCanonical}
+}
+
+// FromBigInt 把 bind.CallOpts.BlockNumber 风格的参数转换为选择器：nil 为 Latest，负数为 rpc 中对应的标签
+func FromBigInt(number *big.Int) Selector {
+	if number == nil {
+		return Latest
+	}
+	return tag(rpc.BlockNumber(number.Int64()))
+}
+
+// Parse 解析命令行或配置中的选择器
+// 支持 latest、pending、safe、finalized、earliest、十进制或 0x 十六进制区块号、区块哈希；
+// 区块哈希后加 "!" 表示 requireCanonical，例如 0xabc…def!
+func Parse(s string) (Selector, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "", "latest":
+		return Latest, nil
+	case "pending":
+		return Pending, nil
+	case "safe":
+		return Safe, nil
+	case "finalized":
+		return Finalized, nil
+	case "earliest":
+		return Earliest, nil
+	}
+	canonical := strings.HasSuffix(s, "!")
+	raw := strings.TrimSuffix(s, "!")
+	if len(raw) == 66 && strings.HasPrefix(raw, "0x") {
+		var hash common.Hash
+		if err := hash.UnmarshalText([]byte(raw)); err != nil {
+			return Selector{}, fmt.Errorf("invalid block hash %q: %w", raw, err)
+		}
+		return Hash(hash, canonical), nil
+	}
+	if canonical {
+		return Selector{}, fmt.Errorf("requireCanonical only applies to block hashes: %q", s)
+	}
+	var (
+		n   uint64
+		err error
+	)
+	if strings.HasPrefix(raw, "0x") {
+		n, err = hexutil.DecodeUint64(raw)
+	} else {
+		n, err = strconv.ParseUint(raw, 10, 63)
+	}
+	if err != nil {
+		return Selector{}, fmt.Errorf("invalid block selector %q", s)
+	}
+	return Number(n), nil
+}
+
+// IsZero 是否未指定
+func (s Selector) IsZero() bool {
+	return s.number == nil && s.hash == nil
+}
+
+// BlockNumber 返回区块号或标签，按哈希选择时 ok 为 false
+func (s Selector) BlockNumber() (rpc.BlockNumber, bool) {
+	if s.number == nil {
+		return 0, false
+	}
+	return *s.number, true
+}
+
+// BlockHash 返回区块哈希，按区块号或标签选择时 ok 为 false
+func (s Selector) BlockHash() (common.Hash, bool) {
+	if s.hash == nil {
+		return common.Hash{}, false
+	}
+	return *s.hash, true
+}
+
+// RequireCanonical 按哈希选择时是否要求该区块在主链上
+func (s Selector) RequireCanonical() bool {
+	return s.hash != nil && s.requireCanonical
+}
+
+// IsTag 是否为 latest/pending/safe/finalized/earliest 等标签（结果随链头变化）
+func (s Selector) IsTag() bool {
+	return s.number != nil && *s.number < 0
+}
+
+// BlockNumberOrHash 转换为 go-ethereum 的 rpc 参数类型
+func (s Selector) BlockNumberOrHash() rpc.BlockNumberOrHash {
+	if s.hash != nil {
+		return rpc.BlockNumberOrHashWithHash(*s.hash, s.requireCanonical)
+	}
+	if s.number != nil {
+		return rpc.BlockNumberOrHashWithNumber(*s.number)
+	}
+	return rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+}
+
+func (s Selector) String() string {
+	switch {
+	case s.hash != nil && s.requireCanonical:
+		return s.hash.Hex() + "!"
+	case s.hash != nil:
+		return s.hash.Hex()
+	case s.number == nil:
+		return "default"
+	case *s.number >= 0:
+		return strconv.FormatInt(int64(*s.number), 10)
+	default:
+		return s.number.String()
+	}
+}
+
+// MarshalJSON 区块号和标签编码为字符串（兼容不支持 EIP-1898 的节点），区块哈希编码为 EIP-1898 对象
+func (s Selector) MarshalJSON() ([]byte, error) {
+	if s.hash != nil {
+		return json.Marshal(struct {
+			BlockHash        common.Hash `json:"blockHash"`
+			RequireCanonical bool        `json:"requireCanonical,omitempty"`
+		}{*s.hash, s.requireCanonical})
+	}
+	if s.number == nil {
+		return json.Marshal(rpc.LatestBlockNumber.String())
+	}
+	return json.Marshal(s.number.String())
+}
@@ -0,0 +1,36 @@
+package blocktime
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DeploymentBlock 二分查找合约代码首次出现的区块，需要归档节点
+// 回放合约事件（holders.Indexer、storekv.Mirror、nft.Indexer）时用作起始区块
+func DeploymentBlock(ctx context.Context, caller bind.ContractCaller, address common.Address, latest uint64) (uint64, error) {
+	code, err := caller.CodeAt(ctx, address, new(big.Int).SetUint64(latest))
+	if err != nil {
+		return 0, err
+	}
+	if len(code) == 0 {
+		return 0, fmt.Errorf("no contract code at %s", address.Hex())
+	}
+	lo, hi := uint64(0), latest // code(lo) 可能为空，code(hi) 一定非空
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		code, err := caller.CodeAt(ctx, address, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, err
+		}
+		if len(code) > 0 {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi, nil
+}
@@ -0,0 +1,199 @@
+package blocktime
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/blockref"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultBlockTime 合并（The Merge）之后以太坊主网及 Sepolia 的出块间隔
+const DefaultBlockTime = 12 * time.Second
+
+var (
+	// ErrFutureTime 目标时间晚于最新区块
+	ErrFutureTime = errors.New("time is after the head block")
+	// ErrBeforeGenesis 目标时间早于创世区块
+	ErrBeforeGenesis = errors.New("time is before the genesis block")
+)
+
+// HeaderReader 按区块号读取区块头，*ethclient.Client 满足该接口
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Finder 通过二分查找把时间戳映射为区块号
+// 先根据平均出块时间估算初始位置，再向两侧扩展出区间后二分，已查询过的区块时间会被缓存
+type Finder struct {
+	reader HeaderReader
+
+	mu    sync.Mutex
+	times map[uint64]uint64 // 区块号 -> 时间戳
+
+	AvgBlockTime time.Duration     // 用于估算初始位置的平均出块时间
+	Head         blockref.Selector // 查找范围的上界，零值为 latest；设为 Finalized 时只返回已最终确定的区块
+}
+
+// NewFinder 创建查找器
+func NewFinder(reader HeaderReader) *Finder {
+	return &Finder{reader: reader, times: make(map[uint64]uint64), AvgBlockTime: DefaultBlockTime}
+}
+
+// BlockAtOrAfter 返回时间戳大于等于 t 的第一个区块号，t 早于 1970 年时所有区块都满足，返回 0
+func (f *Finder) BlockAtOrAfter(ctx context.Context, t time.Time) (uint64, error) {
+	// 区块时间戳是无符号数，负的 Unix 时间直接转换会回绕成极大的值
+	target := uint64(max(t.Unix(), 0))
+	latest, err := blockref.Header(ctx, f.reader, f.Head)
+	if err != nil {
+		return 0, err
+	}
+	f.remember(latest.Number.Uint64(), latest.Time)
+	if target > latest.Time {
+		return 0, fmt.Errorf("%w: %s > %s", ErrFutureTime, t.UTC().Format(time.RFC3339), time.Unix(int64(latest.Time), 0).UTC().Format(time.RFC3339))
+	}
+	return f.search(ctx, latest.Number.Uint64(), latest.Time, target)
+}
+
+// BlockAtOrBefore 返回时间戳小于等于 t 的最后一个区块号，即 t 时刻链上的最新状态所在区块
+func (f *Finder) BlockAtOrBefore(ctx context.Context, t time.Time) (uint64, error) {
+	if t.Unix() < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrBeforeGenesis, t.UTC().Format(time.RFC3339))
+	}
+	latest, err := blockref.Header(ctx, f.reader, f.Head)
+	if err != nil {
+		return 0, err
+	}
+	f.remember(latest.Number.Uint64(), latest.Time)
+	target := uint64(t.Unix())
+	if target >= latest.Time {
+		return latest.Number.Uint64(), nil
+	}
+	// 第一个时间戳大于 t 的区块的前一个区块
+	next, err := f.search(ctx, latest.Number.Uint64(), latest.Time, target+1)
+	if err != nil {
+		return 0, err
+	}
+	if next == 0 {
+		return 0, ErrBeforeGenesis
+	}
+	return next - 1, nil
+}
+
+// BlockRange 返回时间区间 [from, to] 内的区块号范围，用于按日期查询事件
+func (f *Finder) BlockRange(ctx context.Context, from, to time.Time) (uint64, uint64, error) {
+	if to.Before(from) {
+		return 0, 0, fmt.Errorf("invalid range: %s is before %s", to, from)
+	}
+	start, err := f.BlockAtOrAfter(ctx, from)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := f.BlockAtOrBefore(ctx, to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("no blocks between %s and %s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	}
+	return start, end, nil
+}
+
+// search 在 [0, latest] 中查找时间戳 >= target 的第一个区块，要求 target <= latestTime
+func (f *Finder) search(ctx context.Context, latest, latestTime, target uint64) (uint64, error) {
+	// 根据平均出块时间估算初始位置
+	guess := latest
+	if avg := uint64(f.AvgBlockTime / time.Second); avg > 0 {
+		if behind := (latestTime - target) / avg; behind < latest {
+			guess = latest - behind
+		} else {
+			guess = 0
+		}
+	}
+
+	// 以估算位置为起点，步长加倍向两侧扩展，找到 lo（时间 < target）和 hi（时间 >= target）
+	guessTime, err := f.timeOf(ctx, guess)
+	if err != nil {
+		return 0, err
+	}
+	var lo, hi uint64
+	if guessTime >= target {
+		hi = guess
+		step := uint64(1)
+		for {
+			if hi == 0 {
+				return 0, nil
+			}
+			lo = 0
+			if hi > step {
+				lo = hi - step
+			}
+			loTime, err := f.timeOf(ctx, lo)
+			if err != nil {
+				return 0, err
+			}
+			if loTime < target {
+				break
+			}
+			if lo == 0 {
+				return 0, nil
+			}
+			hi, step = lo, step*2
+		}
+	} else {
+		lo = guess
+		step := uint64(1)
+		for {
+			hi = min(lo+step, latest)
+			hiTime, err := f.timeOf(ctx, hi)
+			if err != nil {
+				return 0, err
+			}
+			if hiTime >= target {
+				break
+			}
+			lo, step = hi, step*2
+		}
+	}
+
+	// 二分：保持 time(lo) < target <= time(hi)
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		midTime, err := f.timeOf(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if midTime >= target {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
+
+// timeOf 返回区块时间戳，优先使用缓存
+func (f *Finder) timeOf(ctx context.Context, number uint64) (uint64, error) {
+	f.mu.Lock()
+	t, ok := f.times[number]
+	f.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+	header, err := f.reader.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return 0, fmt.Errorf("header %d: %w", number, err)
+	}
+	f.remember(number, header.Time)
+	return header.Time, nil
+}
+
+func (f *Finder) remember(number, timestamp uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.times[number] = timestamp
+}
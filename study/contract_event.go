@@ -7,6 +7,7 @@ package main
 
 import (
 	"context"
+	"eth-client-study/study/addressbook"
 	"fmt"
 	"log"
 	"math/big"
@@ -51,8 +52,9 @@ func main() {
 		log.Fatal(err)
 	}
 
-	// 指定目标合约地址（在 Sepolia 测试网络上部署的 Store 合约）
-	contractAddress := common.HexToAddress("0x183AdfEe585d04Db1Ab151840D6399009beC2bC4")
+	// 指定目标合约地址（在 Sepolia 测试网络上部署的 Store 合约，见地址簿中的 store）
+	book := addressbook.MustOpen(context.Background(), client)
+	contractAddress := book.MustResolve("合约地址", "store")
 
 	// 将合约 ABI 字符串解析为 Go 可操作的对象
 	// abi.ABI 对象提供了对合约函数和事件的操作方法
@@ -0,0 +1,258 @@
+package create2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// 规范的确定性部署代理（github.com/Arachnid/deterministic-deployment-proxy）
+// 通过一笔不带链 ID 的预签名交易部署，因此在所有 EVM 链上地址相同；调用数据为 salt(32 字节) ++ 创建代码，返回新合约地址
+var (
+	// FactoryAddress 代理合约地址
+	FactoryAddress = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+	// FactorySigner 预签名部署交易的发送方，没有人持有其私钥
+	FactorySigner = common.HexToAddress("0x3fAB184622Dc19b6109349B94811493BF2a45362")
+	// FactoryDeploymentCost 发送方需要的 ETH：gasLimit 100000 × gasPrice 100 gwei
+	FactoryDeploymentCost = new(big.Int).Mul(big.NewInt(100000), big.NewInt(100*params.GWei))
+)
+
+// factoryDeploymentTx 预签名的部署交易，r 和 s 都是 0x22…22，发送方由签名反推得出
+const factoryDeploymentTx = "0xf8a58085174876e800830186a08080b853604580600e600039806000f350fe7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf31ba02222222222222222222222222222222222222222222222222222222222222222a02222222222222222222222222222222222222222222222222222222222222222"
+
+// factoryCode 代理合约的运行时代码
+var factoryCode = common.FromHex("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+
+var (
+	// ErrNoFactory 链上没有工厂合约，可先调用 EnsureFactory
+	ErrNoFactory = errors.New("create2 factory is not deployed")
+	// ErrFactoryCode 工厂地址上的代码不是确定性部署代理
+	ErrFactoryCode = errors.New("unexpected code at create2 factory address")
+	// ErrSignerUsed 预签名交易的发送方 nonce 已不为 0，这笔交易无法再上链
+	ErrSignerUsed = errors.New("factory signer nonce is not zero")
+	// ErrAddressMismatch 工厂返回的地址与预先计算的不一致
+	ErrAddressMismatch = errors.New("create2 address mismatch")
+)
+
+// FactoryDeploymentTx 解码后的预签名部署交易
+func FactoryDeploymentTx() *types.Transaction {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(hexutil.MustDecode(factoryDeploymentTx)); err != nil {
+		panic(err)
+	}
+	return tx
+}
+
+// InitCode 合约的创建代码：abigen 生成的 Bin 加上按 ABI 编码的构造参数，构造参数不同则地址不同
+func InitCode(meta *bind.MetaData, args ...any) ([]byte, error) {
+	parsed, err := meta.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	input, err := parsed.Pack("", args...)
+	if err != nil {
+		return nil, fmt.Errorf("pack constructor args: %w", err)
+	}
+	return append(common.FromHex(meta.Bin), input...), nil
+}
+
+// Address 由 factory 用 CREATE2 部署 initCode 得到的地址：keccak256(0xff ++ factory ++ salt ++ keccak256(initCode))[12:]
+func Address(factory common.Address, salt common.Hash, initCode []byte) common.Address {
+	return crypto.CreateAddress2(factory, salt, crypto.Keccak256(initCode))
+}
+
+// ParseSalt 0x 开头时按 32 字节十六进制解析，否则取 keccak256(s)，便于用 "store-v1" 这样的名字作 salt
+func ParseSalt(s string) (common.Hash, error) {
+	if strings.HasPrefix(s, "0x") {
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("salt %q: %w", s, err)
+		}
+		if len(b) != common.HashLength {
+			return common.Hash{}, fmt.Errorf("salt %q has %d bytes, want 32", s, len(b))
+		}
+		return common.BytesToHash(b), nil
+	}
+	return crypto.Keccak256Hash([]byte(s)), nil
+}
+
+// Backend 部署所需的链上接口，*ethclient.Client 和模拟链的客户端都满足
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// Deployment 一次 CREATE2 部署的结果
+type Deployment struct {
+	Address      common.Address
+	Salt         common.Hash
+	InitCodeHash common.Hash
+	Existing     bool               // 地址上已有代码，没有发送交易
+	Tx           *types.Transaction // Existing 时为空
+	Receipt      *types.Receipt
+}
+
+// Deployer 通过 CREATE2 工厂部署合约，地址只取决于工厂、salt 和创建代码，与部署账户及其 nonce 无关
+type Deployer struct {
+	backend Backend
+
+	Factory common.Address
+	// Wait 等待交易上链，默认 bind.WaitMined；模拟链需要手动出块，可设为 Harness.WaitMined
+	Wait func(ctx context.Context, tx *types.Transaction) (*types.Receipt, error)
+}
+
+// NewDeployer 创建使用规范确定性部署代理的部署器
+func NewDeployer(backend Backend) *Deployer {
+	return &Deployer{
+		backend: backend,
+		Factory: FactoryAddress,
+		Wait: func(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+			return bind.WaitMined(ctx, backend, tx)
+		},
+	}
+}
+
+// wait 等待交易上链并检查执行状态
+func (d *Deployer) wait(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := d.Wait(ctx, tx)
+	if err != nil {
+		return receipt, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// EnsureFactory 工厂不存在时部署规范的确定性部署代理，返回是否发送了部署交易
+// 先由 opts 账户给预签名交易的发送方补足 FactoryDeploymentCost，再广播预签名交易；
+// 节点需要接受不带链 ID 的交易（geth 的 --rpc.allow-unprotected-txs），主网和主流测试网上代理早已部署，不会走到这一步
+func (d *Deployer) EnsureFactory(opts *bind.TransactOpts) (bool, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	code, err := d.backend.CodeAt(ctx, d.Factory, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) > 0 {
+		if d.Factory == FactoryAddress && !bytes.Equal(code, factoryCode) {
+			return false, fmt.Errorf("%w: %s", ErrFactoryCode, d.Factory.Hex())
+		}
+		return false, nil
+	}
+	if d.Factory != FactoryAddress {
+		return false, fmt.Errorf("%w at %s", ErrNoFactory, d.Factory.Hex())
+	}
+
+	nonce, err := d.backend.NonceAt(ctx, FactorySigner, nil)
+	if err != nil {
+		return false, err
+	}
+	if nonce != 0 {
+		return false, fmt.Errorf("%w: %d", ErrSignerUsed, nonce)
+	}
+	balance, err := d.backend.BalanceAt(ctx, FactorySigner, nil)
+	if err != nil {
+		return false, err
+	}
+	if balance.Cmp(FactoryDeploymentCost) < 0 {
+		fund := *opts
+		fund.Value = new(big.Int).Sub(FactoryDeploymentCost, balance)
+		fund.GasLimit = params.TxGas // 普通转账；不指定时 bind 会因目标地址没有代码而拒绝估算 gas
+		tx, err := bind.NewBoundContract(FactorySigner, abi.ABI{}, d.backend, d.backend, d.backend).RawTransact(&fund, nil)
+		if err != nil {
+			return false, fmt.Errorf("fund factory signer: %w", err)
+		}
+		if _, err := d.wait(ctx, tx); err != nil {
+			return false, fmt.Errorf("fund factory signer: %w", err)
+		}
+	}
+
+	tx := FactoryDeploymentTx()
+	if err := d.backend.SendTransaction(ctx, tx); err != nil {
+		return false, fmt.Errorf("send factory deployment: %w", err)
+	}
+	if _, err := d.wait(ctx, tx); err != nil {
+		return true, fmt.Errorf("factory deployment: %w", err)
+	}
+	if code, err = d.backend.CodeAt(ctx, d.Factory, nil); err != nil {
+		return true, err
+	}
+	if !bytes.Equal(code, factoryCode) {
+		return true, fmt.Errorf("%w: %s", ErrFactoryCode, d.Factory.Hex())
+	}
+	return true, nil
+}
+
+// Predict 预先计算部署地址，不访问链
+func (d *Deployer) Predict(salt common.Hash, initCode []byte) common.Address {
+	return Address(d.Factory, salt, initCode)
+}
+
+// Deploy 通过工厂部署 initCode；目标地址已有代码时直接返回（Existing 为 true），同一 salt 和创建代码重复执行是安全的
+// 发送前模拟调用工厂，确认构造函数不会回滚且返回的地址与预先计算的一致
+func (d *Deployer) Deploy(opts *bind.TransactOpts, salt common.Hash, initCode []byte) (*Deployment, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	deployment := &Deployment{
+		Address:      d.Predict(salt, initCode),
+		Salt:         salt,
+		InitCodeHash: crypto.Keccak256Hash(initCode),
+	}
+	code, err := d.backend.CodeAt(ctx, deployment.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) > 0 {
+		deployment.Existing = true
+		return deployment, nil
+	}
+	if code, err = d.backend.CodeAt(ctx, d.Factory, nil); err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("%w at %s", ErrNoFactory, d.Factory.Hex())
+	}
+
+	data := append(salt.Bytes(), initCode...)
+	out, err := d.backend.CallContract(ctx, ethereum.CallMsg{From: opts.From, To: &d.Factory, Value: opts.Value, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("simulate deployment: %w", err)
+	}
+	if got := common.BytesToAddress(out); len(out) != common.AddressLength || got != deployment.Address {
+		return nil, fmt.Errorf("%w: factory returned %x, want %s", ErrAddressMismatch, out, deployment.Address.Hex())
+	}
+
+	contract := bind.NewBoundContract(d.Factory, abi.ABI{}, d.backend, d.backend, d.backend)
+	if deployment.Tx, err = contract.RawTransact(opts, data); err != nil {
+		return nil, err
+	}
+	if deployment.Receipt, err = d.wait(ctx, deployment.Tx); err != nil {
+		return deployment, err
+	}
+	if code, err = d.backend.CodeAt(ctx, deployment.Address, nil); err != nil {
+		return deployment, err
+	}
+	if len(code) == 0 {
+		return deployment, fmt.Errorf("%w: no code at %s after deployment", ErrAddressMismatch, deployment.Address.Hex())
+	}
+	return deployment, nil
+}
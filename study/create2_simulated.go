@@ -0,0 +1,106 @@
+// create2_simulated.go - 在离线模拟链上演示通过确定性部署代理（CREATE2）部署 Store 和 Counter
+// 合约地址只取决于 salt 和创建代码（含构造参数），与部署账户和 nonce 无关，与在 Sepolia 上用相同参数部署时一致
+// 用法：go run study/create2_simulated.go
+package main
+
+import (
+	"context"
+	"eth-client-study/study/create2"
+	"eth-client-study/study/simulated"
+	"eth-client-study/study/store"
+	"eth-client-study/task01/counter"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+func main() {
+	harness, err := simulated.NewHarness(2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer harness.Close()
+
+	// 模拟链上没有代理合约，先用预签名交易部署，地址与所有网络上的相同
+	opts, err := harness.TransactOpts(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	deployer := harness.Create2Deployer()
+	deployed, err := deployer.EnsureFactory(opts)
+	if err != nil {
+		log.Fatal("部署代理失败：", err)
+	}
+	fmt.Printf("代理合约：%s（本次部署：%v）\n", deployer.Factory.Hex(), deployed)
+
+	salt, err := create2.ParseSalt("store")
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 先用普通的 CREATE 部署一次，nonce 改变后再用 CREATE2 部署，地址不受影响
+	plain, _, err := harness.DeployStore("1.0")
+	if err != nil {
+		log.Fatal("部署Store失败：", err)
+	}
+	fmt.Println("CREATE 部署的 Store：", plain.Hex())
+
+	initCode, err := create2.InitCode(store.StoreMetaData, "1.0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("预先计算的 Store 地址：", create2.Address(create2.FactoryAddress, salt, initCode).Hex())
+	deployment, instance, err := harness.DeployStoreCreate2(salt, "1.0")
+	if err != nil {
+		log.Fatal("CREATE2 部署Store失败：", err)
+	}
+	version, err := instance.Version(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("CREATE2 部署的 Store：%s，version=%s，交易：%s\n", deployment.Address.Hex(), version, deployment.Tx.Hash().Hex())
+
+	// 同样的 salt 和构造参数再部署一次：地址上已有代码，跳过
+	again, _, err := harness.DeployStoreCreate2(salt, "1.0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("再次部署：%s，已存在：%v\n", again.Address.Hex(), again.Existing)
+
+	// 构造参数不同，创建代码不同，地址也不同
+	v2, _, err := harness.DeployStoreCreate2(salt, "2.0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("version=2.0 的 Store：", v2.Address.Hex())
+
+	// 换一个账户部署 Counter，地址同样只取决于 salt
+	counterSalt, err := create2.ParseSalt("counter")
+	if err != nil {
+		log.Fatal(err)
+	}
+	other, err := harness.TransactOpts(1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	counterCode, err := create2.InitCode(counter.CounterMetaData)
+	if err != nil {
+		log.Fatal(err)
+	}
+	counterDeployment, err := deployer.Deploy(other, counterSalt, counterCode)
+	if err != nil {
+		log.Fatal("CREATE2 部署Counter失败：", err)
+	}
+	fmt.Printf("账户 %s 部署的 Counter：%s\n", other.From.Hex(), counterDeployment.Address.Hex())
+
+	// 通过 harness 用第一个账户部署同样的 Counter：地址相同，已存在
+	same, instance2, err := harness.DeployCounterCreate2(counterSalt)
+	if err != nil {
+		log.Fatal(err)
+	}
+	count, err := instance2.GetCount(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("账户 %s 部署的 Counter：%s，已存在：%v，count=%s\n", harness.Accounts[0].Hex(), same.Address.Hex(), same.Existing, count)
+}
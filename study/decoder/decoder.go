@@ -0,0 +1,292 @@
+package decoder
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"eth-client-study/utils"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//go:embed signatures.txt
+var builtinSignatures string
+
+var (
+	// ErrNoSelector 调用数据不足 4 字节，无法取出函数选择器（如普通 ETH 转账）
+	ErrNoSelector = errors.New("calldata shorter than 4 bytes")
+	// ErrUnknownSelector 既没有注册的 ABI 也没有签名库能识别该选择器
+	ErrUnknownSelector = errors.New("unknown function selector")
+)
+
+// 解析来源
+const (
+	SourceABI       = "abi"       // 来自为目标地址注册的 ABI
+	SourceSignature = "signature" // 来自 4 字节签名库
+)
+
+// SignatureDB 4 字节函数签名库，同一个选择器可能对应多个签名（碰撞）
+type SignatureDB struct {
+	mu      sync.RWMutex
+	methods map[[4]byte][]abi.Method
+}
+
+// NewSignatureDB 创建一个空的签名库
+func NewSignatureDB() *SignatureDB {
+	return &SignatureDB{methods: make(map[[4]byte][]abi.Method)}
+}
+
+// DefaultSignatureDB 创建一个预先加载了内置签名的签名库
+func DefaultSignatureDB() *SignatureDB {
+	db := NewSignatureDB()
+	if err := db.Load(strings.NewReader(builtinSignatures)); err != nil {
+		panic(fmt.Sprintf("decoder: invalid builtin signatures: %v", err))
+	}
+	return db
+}
+
+// EnvSignatureFile 指定自定义签名文件的环境变量名（可写在 .env 中），文件中的签名追加到内置签名库
+const EnvSignatureFile = "SIGNATURE_FILE"
+
+// SignatureDBFromEnv 创建预先加载了内置签名的签名库，设置了 SIGNATURE_FILE 时再加载该文件
+func SignatureDBFromEnv() (*SignatureDB, error) {
+	db := DefaultSignatureDB()
+	if path := utils.GetEnvOrDefault(EnvSignatureFile, ""); path != "" {
+		if err := db.LoadFile(path); err != nil {
+			return nil, fmt.Errorf("%s: %w", EnvSignatureFile, err)
+		}
+	}
+	return db, nil
+}
+
+// AddSignature 向签名库添加一个文本签名，重复添加会被忽略
+func (db *SignatureDB) AddSignature(sig string) error {
+	method, err := ParseSignature(sig)
+	if err != nil {
+		return err
+	}
+	var selector [4]byte
+	copy(selector[:], method.ID)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, m := range db.methods[selector] {
+		if m.Sig == method.Sig {
+			return nil
+		}
+	}
+	db.methods[selector] = append(db.methods[selector], method)
+	return nil
+}
+
+// Load 从 reader 中按行加载签名
+func (db *SignatureDB) Load(r io.Reader) error {
+	sigs, err := readSignatures(r)
+	if err != nil {
+		return err
+	}
+	for _, sig := range sigs {
+		if err := db.AddSignature(sig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile 从本地文件加载签名，用于扩展内置签名库
+func (db *SignatureDB) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := db.Load(f); err != nil {
+		return fmt.Errorf("load %s: %w", path, err)
+	}
+	return nil
+}
+
+// Lookup 返回选择器对应的全部候选方法
+func (db *SignatureDB) Lookup(selector [4]byte) []abi.Method {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return append([]abi.Method(nil), db.methods[selector]...)
+}
+
+// Arg 解析出的单个参数
+type Arg struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// DecodedCall 一次解析结果
+type DecodedCall struct {
+	Source string     // 解析来源：SourceABI 或 SourceSignature
+	Method abi.Method // 匹配到的方法
+	Args   []Arg      // 按顺序排列的参数
+}
+
+// String 以 name(arg: value, ...) 的形式输出解析结果
+func (c *DecodedCall) String() string {
+	parts := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		parts[i] = fmt.Sprintf("%s: %s", arg.Name, FormatValue(arg.Value))
+	}
+	return fmt.Sprintf("%s(%s)", c.Method.RawName, strings.Join(parts, ", "))
+}
+
+// Decoder 交易调用数据解析器
+// 优先使用为目标地址注册的 ABI，其次回退到 4 字节签名库
+type Decoder struct {
+	mu   sync.RWMutex
+	abis map[common.Address]*abi.ABI
+	db   *SignatureDB
+}
+
+// NewDecoder 创建解析器，db 为 nil 时使用内置签名库
+func NewDecoder(db *SignatureDB) *Decoder {
+	if db == nil {
+		db = DefaultSignatureDB()
+	}
+	return &Decoder{abis: make(map[common.Address]*abi.ABI), db: db}
+}
+
+// Signatures 返回解析器使用的签名库，可用于追加签名
+func (d *Decoder) Signatures() *SignatureDB {
+	return d.db
+}
+
+// RegisterABI 为合约地址注册 ABI
+func (d *Decoder) RegisterABI(address common.Address, contractABI *abi.ABI) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.abis[address] = contractABI
+}
+
+// RegisterABIJSON 以 JSON 字符串形式为合约地址注册 ABI，如 store.StoreMetaData.ABI
+func (d *Decoder) RegisterABIJSON(address common.Address, abiJSON string) error {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return err
+	}
+	d.RegisterABI(address, &contractABI)
+	return nil
+}
+
+// Decode 解析发往 to 的调用数据
+// 注册的 ABI 能解析时只返回该结果；否则返回签名库中所有能干净解析的候选，
+// 选择器碰撞时可能有多个结果
+func (d *Decoder) Decode(to *common.Address, data []byte) ([]*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, ErrNoSelector
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+
+	if to != nil {
+		d.mu.RLock()
+		contractABI := d.abis[*to]
+		d.mu.RUnlock()
+		if contractABI != nil {
+			if method, err := contractABI.MethodById(selector[:]); err == nil {
+				if call, err := decodeWith(*method, data[4:], false); err == nil {
+					call.Source = SourceABI
+					return []*DecodedCall{call}, nil
+				}
+			}
+		}
+	}
+
+	candidates := d.db.Lookup(selector)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSelector, hexutil.Encode(selector[:]))
+	}
+	var calls []*DecodedCall
+	for _, method := range candidates {
+		call, err := decodeWith(method, data[4:], true)
+		if err != nil {
+			continue
+		}
+		call.Source = SourceSignature
+		calls = append(calls, call)
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("selector %s matched %d signatures but none decoded cleanly", hexutil.Encode(selector[:]), len(candidates))
+	}
+	return calls, nil
+}
+
+// decodeWith 使用指定方法解析参数
+// strict 为 true 时要求重新编码后与原始数据完全一致，用于排除碰撞签名的误解析
+func decodeWith(method abi.Method, payload []byte, strict bool) (*DecodedCall, error) {
+	values, err := method.Inputs.Unpack(payload)
+	if err != nil {
+		return nil, err
+	}
+	if strict {
+		repacked, err := method.Inputs.Pack(values...)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(repacked, payload) {
+			return nil, errors.New("calldata does not round-trip")
+		}
+	}
+	call := &DecodedCall{Method: method, Args: make([]Arg, len(values))}
+	for i, value := range values {
+		input := method.Inputs[i]
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		call.Args[i] = Arg{Name: name, Type: input.Type.String(), Value: value}
+	}
+	return call, nil
+}
+
+// FormatValue 将 ABI 解码出的值格式化为便于阅读的字符串
+func FormatValue(v interface{}) string {
+	switch val := v.(type) {
+	case common.Address:
+		return val.Hex()
+	case *big.Int:
+		return val.String()
+	case []byte:
+		return hexutil.Encode(val)
+	case string:
+		return fmt.Sprintf("%q", val)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = FormatValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case reflect.Struct:
+		parts := make([]string, rv.NumField())
+		for i := range parts {
+			parts[i] = FormatValue(rv.Field(i).Interface())
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,143 @@
+package decoder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ParseSignature 将文本函数签名（如 "transfer(address,uint256)"）解析为 abi.Method
+// 文本签名没有参数名，参数依次命名为 arg0、arg1...；支持 tuple 及数组类型
+func ParseSignature(sig string) (abi.Method, error) {
+	sig = strings.ReplaceAll(strings.TrimSpace(sig), " ", "")
+	open := strings.IndexByte(sig, '(')
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return abi.Method{}, fmt.Errorf("invalid signature %q", sig)
+	}
+	name := sig[:open]
+	params, err := splitParams(sig[open+1 : len(sig)-1])
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	inputs := make(abi.Arguments, 0, len(params))
+	for i, param := range params {
+		typ, err := parseType(param)
+		if err != nil {
+			return abi.Method{}, fmt.Errorf("invalid signature %q: %w", sig, err)
+		}
+		inputs = append(inputs, abi.Argument{Name: fmt.Sprintf("arg%d", i), Type: typ})
+	}
+	return abi.NewMethod(name, name, abi.Function, "nonpayable", false, false, inputs, nil), nil
+}
+
+// parseType 解析单个参数类型，tuple 写作 "(t1,t2)" 并可带数组后缀
+func parseType(s string) (abi.Type, error) {
+	if !strings.HasPrefix(s, "(") {
+		return abi.NewType(s, "", nil)
+	}
+	end := matchingParen(s)
+	if end < 0 {
+		return abi.Type{}, fmt.Errorf("unbalanced tuple %q", s)
+	}
+	components, err := tupleComponents(s[1:end])
+	if err != nil {
+		return abi.Type{}, err
+	}
+	return abi.NewType("tuple"+s[end+1:], "", components)
+}
+
+// tupleComponents 把 tuple 内部的类型列表转换为 abi.ArgumentMarshaling
+func tupleComponents(inner string) ([]abi.ArgumentMarshaling, error) {
+	params, err := splitParams(inner)
+	if err != nil {
+		return nil, err
+	}
+	components := make([]abi.ArgumentMarshaling, 0, len(params))
+	for i, param := range params {
+		component := abi.ArgumentMarshaling{Name: fmt.Sprintf("field%d", i), Type: param}
+		if strings.HasPrefix(param, "(") {
+			end := matchingParen(param)
+			if end < 0 {
+				return nil, fmt.Errorf("unbalanced tuple %q", param)
+			}
+			component.Type = "tuple" + param[end+1:]
+			if component.Components, err = tupleComponents(param[1:end]); err != nil {
+				return nil, err
+			}
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// splitParams 按顶层逗号切分参数列表，忽略括号内部的逗号
+func splitParams(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var (
+		params []string
+		depth  int
+		start  int
+	)
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %q", s)
+			}
+		case ',':
+			if depth == 0 {
+				params = append(params, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %q", s)
+	}
+	params = append(params, s[start:])
+	for _, p := range params {
+		if p == "" {
+			return nil, fmt.Errorf("empty parameter in %q", s)
+		}
+	}
+	return params, nil
+}
+
+// matchingParen 返回与 s[0] 处左括号匹配的右括号下标，找不到返回 -1
+func matchingParen(s string) int {
+	depth := 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// readSignatures 逐行读取签名文件，空行和 # 开头的注释行会被忽略
+func readSignatures(r io.Reader) ([]string, error) {
+	var sigs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sigs = append(sigs, line)
+	}
+	return sigs, scanner.Err()
+}
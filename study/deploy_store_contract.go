@@ -1,11 +1,21 @@
+// deploy_store_contract.go - 部署 Store 合约
+// 默认用普通的 CREATE 部署，地址随部署账户的 nonce 变化；-create2 时通过确定性部署代理用 CREATE2 部署，
+// 地址只取决于 salt 和创建代码（含构造参数 version），在任何网络上都相同，目标地址已有代码时跳过部署
+// 用法：
+//
+//	go run study/deploy_store_contract.go
+//	go run study/deploy_store_contract.go -create2 -salt store-v1 -version 1.0
+//	go run study/deploy_store_contract.go -create2 -salt store-v1 -predict
 package main
 
 import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
+	"eth-client-study/study/create2"
 	"eth-client-study/study/store"
 	"eth-client-study/utils"
+	"flag"
 	"fmt"
 	"log"
 	"math/big"
@@ -22,6 +32,16 @@ import (
 const byteCode = "608060405234801561000f575f5ffd5b5060405161087838038061087883398181016040528101906100319190610193565b805f908161003f91906103ea565b50506104b9565b5f604051905090565b5f5ffd5b5f5ffd5b5f5ffd5b5f5ffd5b5f601f19601f8301169050919050565b7f4e487b71000000000000000000000000000000000000000000000000000000005f52604160045260245ffd5b6100a58261005f565b810181811067ffffffffffffffff821117156100c4576100c361006f565b5b80604052505050565b5f6100d6610046565b90506100e2828261009c565b919050565b5f67ffffffffffffffff8211156101015761010061006f565b5b61010a8261005f565b9050602081019050919050565b8281835e5f83830152505050565b5f610137610132846100e7565b6100cd565b9050828152602081018484840111156101535761015261005b565b5b61015e848285610117565b509392505050565b5f82601f83011261017a57610179610057565b5b815161018a848260208601610125565b91505092915050565b5f602082840312156101a8576101a761004f565b5b5f82015167ffffffffffffffff8111156101c5576101c4610053565b5b6101d184828501610166565b91505092915050565b5f81519050919050565b7f4e487b71000000000000000000000000000000000000000000000000000000005f52602260045260245ffd5b5f600282049050600182168061022857607f821691505b60208210810361023b5761023a6101e4565b5b50919050565b5f819050815f5260205f209050919050565b5f6020601f8301049050919050565b5f82821b905092915050565b5f6008830261029d7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff82610262565b6102a78683610262565b95508019841693508086168417925050509392505050565b5f819050919050565b5f819050919050565b5f6102eb6102e66102e1846102bf565b6102c8565b6102bf565b9050919050565b5f819050919050565b610304836102d1565b610318610310826102f2565b84845461026e565b825550505050565b5f5f905090565b61032f610320565b61033a8184846102fb565b505050565b5b8181101561035d576103525f82610327565b600181019050610340565b5050565b601f8211156103a25761037381610241565b61037c84610253565b8101602085101561038b578190505b61039f61039785610253565b83018261033f565b50505b505050565b5f82821c905092915050565b5f6103c25f19846008026103a7565b1980831691505092915050565b5f6103da83836103b3565b9150826002028217905092915050565b6103f3826101da565b67ffffffffffffffff81111561040c5761040b61006f565b5b6104168254610211565b610421828285610361565b5f60209050601f831160018114610452575f8415610440578287015190505b61044a85826103cf565b8655506104b1565b601f19841661046086610241565b5f5b8281101561048757848901518255600182019150602085019450602081019050610462565b868310156104a457848901516104a0601f8916826103b3565b8355505b6001600288020188555050505b505050505050565b6103b2806104c65f395ff3fe608060405234801561000f575f5ffd5b506004361061003f575f3560e01c806348f343f31461004357806354fd4d5014610073578063f56256c714610091575b5f5ffd5b61005d600480360381019061005891906101d7565b6100ad565b60405161006a9190610211565b60405180910390f35b61007b6100c2565b604051610088919061029a565b60405180910390f35b6100ab60048036038101906100a691906102ba565b61014d565b005b6001602052805f5260405f205f915090505481565b5f80546100ce90610325565b80601f01602080910402602001604051908101604052809291908181526020018280546100fa90610325565b80156101455780601f1061011c57610100808354040283529160200191610145565b820191905f5260205f20905b81548152906001019060200180831161012857829003601f168201915b505050505081565b8060015f8481526020019081526020015f20819055507fe79e73da417710ae99aa2088575580a60415d359acfad9cdd3382d59c80281d48282604051610194929190610355565b60405180910390a15050565b5f5ffd5b5f819050919050565b6101b6816101a4565b81146101c0575f5ffd5b50565b5f813590506101d1816101ad565b92915050565b5f602082840312156101ec576101eb6101a0565b5b5f6101f9848285016101c3565b91505092915050565b61020b816101a4565b82525050565b5f6020820190506102245f830184610202565b92915050565b5f81519050919050565b5f82825260208201905092915050565b8281835e5f83830152505050565b5f601f19601f8301169050919050565b5f61026c8261022a565b6102768185610234565b9350610286818560208601610244565b61028f81610252565b840191505092915050565b5f6020820190508181035f8301526102b28184610262565b905092915050565b5f5f604083850312156102d0576102cf6101a0565b5b5f6102dd858286016101c3565b92505060206102ee858286016101c3565b9150509250929050565b7f4e487b71000000000000000000000000000000000000000000000000000000005f52602260045260245ffd5b5f600282049050600182168061033c57607f821691505b60208210810361034f5761034e6102f8565b5b50919050565b5f6040820190506103685f830185610202565b6103756020830184610202565b939250505056fea2646970667358221220d67a4c87e169594c1ccb0cb560c567e9fcf070b68e722c8d10d3068c6084093a64736f6c634300081e0033"
 
 func main() {
+	create2Flag := flag.Bool("create2", false, "通过确定性部署代理用 CREATE2 部署")
+	saltFlag := flag.String("salt", "store-v1", "CREATE2 的 salt：32 字节十六进制，或任意字符串（取 keccak256）")
+	versionFlag := flag.String("version", "1.0", "CREATE2 部署时的构造参数 version，不同的 version 地址不同")
+	predictFlag := flag.Bool("predict", false, "只计算 CREATE2 地址并检查是否已部署，不发送交易")
+	flag.Parse()
+
+	if *create2Flag || *predictFlag {
+		deployStoreByCreate2(*saltFlag, *versionFlag, *predictFlag)
+		return
+	}
 	//deployByAbi()
 	deployStoreByByteCode()
 }
@@ -135,6 +155,70 @@ func deployStoreByByteCode() {
 
 }
 
+// deployStoreByCreate2 通过确定性部署代理部署 Store，链上还没有代理时先用预签名交易部署代理
+func deployStoreByCreate2(saltText, version string, predict bool) {
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	salt, err := create2.ParseSalt(saltText)
+	if err != nil {
+		log.Fatal(err)
+	}
+	initCode, err := create2.InitCode(store.StoreMetaData, version)
+	if err != nil {
+		log.Fatal(err)
+	}
+	deployer := create2.NewDeployer(client)
+	address := deployer.Predict(salt, initCode)
+	fmt.Println("代理合约：", deployer.Factory.Hex())
+	fmt.Println("salt：", salt.Hex())
+	fmt.Println("initCodeHash：", crypto.Keccak256Hash(initCode).Hex())
+	fmt.Println("预先计算的合约地址：", address.Hex())
+	if predict {
+		code, err := client.CodeAt(ctx, address, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("已部署：", len(code) > 0)
+		return
+	}
+
+	privateKey, err := crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1"))
+	if err != nil {
+		log.Fatal("privateKey err:", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts.Context = ctx
+
+	deployed, err := deployer.EnsureFactory(opts)
+	if err != nil {
+		log.Fatal("部署代理合约失败：", err)
+	}
+	if deployed {
+		fmt.Println("链上没有代理合约，已用预签名交易部署")
+	}
+	deployment, err := deployer.Deploy(opts, salt, initCode)
+	if err != nil {
+		log.Fatal("部署失败：", err)
+	}
+	if deployment.Existing {
+		fmt.Printf("合约地址 %s 已存在，跳过部署\n", deployment.Address.Hex())
+		return
+	}
+	fmt.Printf("交易成功！TxHash：%s，ContractAddress：%s\n", deployment.Tx.Hash().Hex(), deployment.Address.Hex())
+}
+
 func waitForReceipt(client *ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
 	for {
 		receipt, err := client.TransactionReceipt(context.Background(), txHash)
@@ -3,12 +3,12 @@ package main
 import (
 	"context"
 	"crypto/ecdsa"
+	"eth-client-study/study/addressbook"
 	"eth-client-study/utils"
 	"fmt"
 	"log"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -20,6 +20,7 @@ func main() {
 		fmt.Println("链接失败", err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 
 	privateKey, err := crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1"))
 	if err != nil {
@@ -45,8 +46,8 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	//收款账户地址 0xfa73ee972cb6a7af855846635ad65427a7009d4e
-	toAddress := common.HexToAddress("0xfa73ee972cb6a7af855846635ad65427a7009d4e")
+	//收款账户：地址簿中的 bob（0xFA73Ee972cB6A7af855846635Ad65427a7009d4e）
+	toAddress := book.MustResolve("收款地址", "bob")
 	var data []byte
 	tx := types.NewTransaction(nonceAt, toAddress, value, gasLimit, gasPrice, data)
 	fmt.Println("tx:", tx.Hash().Hex())
@@ -0,0 +1,243 @@
+package holders
+
+import (
+	token "eth-client-study/study/erc20"
+	"eth-client-study/study/replay"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Transfer 一条 Transfer 事件，From 为零地址表示铸造，To 为零地址表示销毁
+type Transfer struct {
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Value    *big.Int       `json:"value"`
+	Block    uint64         `json:"block"`
+	LogIndex uint           `json:"logIndex"`
+	TxHash   common.Hash    `json:"txHash"`
+}
+
+func (t Transfer) before(o Transfer) bool {
+	return t.Block < o.Block || t.Block == o.Block && t.LogIndex < o.LogIndex
+}
+
+func (t Transfer) position() replay.Position {
+	return replay.Position{Block: t.Block, Index: t.LogIndex}
+}
+
+// Holder 持有人及其余额
+type Holder struct {
+	Address common.Address `json:"address"`
+	Balance *big.Int       `json:"balance"`
+}
+
+// Indexer 通过回放 Transfer 事件计算每个地址的余额和总供应量，并保留每个地址的转账记录
+// 必须从代币部署区块开始回放（见 blocktime.DeploymentBlock），否则之前的余额无从得知，部分地址的余额会是负数
+// Sync、Watch、Rewind 等由 replay.Replayer 提供
+type Indexer struct {
+	*replay.Replayer[Transfer]
+
+	filterer *token.Erc20Filterer
+	caller   *token.Erc20Caller
+	supply   *bind.BoundContract
+	address  common.Address
+	topic    common.Hash // Transfer 的 topic0
+
+	mu          sync.RWMutex
+	balances    map[common.Address]*big.Int   // 不含零地址，余额归零的地址会被删除
+	history     map[common.Address][]Transfer // 地址 -> 按 (区块, 日志下标) 升序的转入和转出
+	totalSupply *big.Int
+}
+
+// NewIndexer 创建索引器
+func NewIndexer(address common.Address, backend bind.ContractBackend) (*Indexer, error) {
+	contract, err := token.NewErc20(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	supply, err := newSupplyContract(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := token.Erc20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	x := &Indexer{
+		filterer:    &contract.Erc20Filterer,
+		caller:      &contract.Erc20Caller,
+		supply:      supply,
+		address:     address,
+		topic:       parsed.Events["Transfer"].ID,
+		balances:    make(map[common.Address]*big.Int),
+		history:     make(map[common.Address][]Transfer),
+		totalSupply: new(big.Int),
+	}
+	query := ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{x.topic}}}
+	x.Replayer = replay.New(backend, query, replay.Handler[Transfer]{Parse: x.parse, Apply: x.apply, Revert: x.revert})
+	return x, nil
+}
+
+// Address 代币地址
+func (x *Indexer) Address() common.Address {
+	return x.address
+}
+
+// Apply 计入一次转账，重复的事件（同一区块同一日志下标）会被忽略
+func (x *Indexer) Apply(t Transfer) {
+	x.Replayer.Apply(t.position(), t)
+}
+
+// Revert 撤销一次转账（对应 Removed 为 true 的事件）
+func (x *Indexer) Revert(t Transfer) {
+	x.Replayer.Revert(t.position())
+}
+
+func (x *Indexer) parse(log types.Log) ([]Transfer, error) {
+	if len(log.Topics) == 0 || log.Topics[0] != x.topic {
+		return nil, nil
+	}
+	event, err := x.filterer.ParseTransfer(log)
+	if err != nil {
+		return nil, fmt.Errorf("parse Transfer: %w", err)
+	}
+	return []Transfer{toTransfer(event)}, nil
+}
+
+func (x *Indexer) apply(t Transfer) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.move(t.From, t.To, t.Value)
+	x.insert(t.From, t)
+	if t.To != t.From {
+		x.insert(t.To, t)
+	}
+}
+
+func (x *Indexer) revert(t Transfer) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.move(t.To, t.From, t.Value)
+	x.remove(t.From, t)
+	if t.To != t.From {
+		x.remove(t.To, t)
+	}
+}
+
+// move 把 value 从 from 记到 to，零地址一侧计入总供应量的增减
+func (x *Indexer) move(from, to common.Address, value *big.Int) {
+	if from == (common.Address{}) {
+		x.totalSupply.Add(x.totalSupply, value)
+	} else {
+		x.add(from, new(big.Int).Neg(value))
+	}
+	if to == (common.Address{}) {
+		x.totalSupply.Sub(x.totalSupply, value)
+	} else {
+		x.add(to, value)
+	}
+}
+
+func (x *Indexer) add(account common.Address, delta *big.Int) {
+	balance, ok := x.balances[account]
+	if !ok {
+		balance = new(big.Int)
+		x.balances[account] = balance
+	}
+	if balance.Add(balance, delta).Sign() == 0 {
+		delete(x.balances, account)
+	}
+}
+
+func (x *Indexer) insert(account common.Address, t Transfer) {
+	transfers := x.history[account]
+	i := sort.Search(len(transfers), func(i int) bool { return !transfers[i].before(t) })
+	transfers = append(transfers, Transfer{})
+	copy(transfers[i+1:], transfers[i:])
+	transfers[i] = t
+	x.history[account] = transfers
+}
+
+func (x *Indexer) remove(account common.Address, t Transfer) {
+	transfers := x.history[account]
+	for i := range transfers {
+		if transfers[i].position() == t.position() {
+			transfers = append(transfers[:i], transfers[i+1:]...)
+			break
+		}
+	}
+	if len(transfers) == 0 {
+		delete(x.history, account)
+	} else {
+		x.history[account] = transfers
+	}
+}
+
+// Balance 根据事件计算的余额
+func (x *Indexer) Balance(account common.Address) *big.Int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if balance, ok := x.balances[account]; ok {
+		return new(big.Int).Set(balance)
+	}
+	return new(big.Int)
+}
+
+// TotalSupply 根据事件计算的总供应量：铸造（From 为零地址）之和减去销毁（To 为零地址）之和
+func (x *Indexer) TotalSupply() *big.Int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return new(big.Int).Set(x.totalSupply)
+}
+
+// Holders 余额不为零的地址数
+func (x *Indexer) Holders() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.balances)
+}
+
+// TopHolders 余额最高的 n 个地址，按余额降序排列，余额相同时按地址排序；n <= 0 时返回全部
+func (x *Indexer) TopHolders(n int) []Holder {
+	x.mu.RLock()
+	holders := make([]Holder, 0, len(x.balances))
+	for account, balance := range x.balances {
+		holders = append(holders, Holder{Address: account, Balance: new(big.Int).Set(balance)})
+	}
+	x.mu.RUnlock()
+	sort.Slice(holders, func(i, j int) bool {
+		if c := holders[i].Balance.Cmp(holders[j].Balance); c != 0 {
+			return c > 0
+		}
+		return holders[i].Address.Cmp(holders[j].Address) < 0
+	})
+	if n > 0 && n < len(holders) {
+		holders = holders[:n]
+	}
+	return holders
+}
+
+// History account 的全部转入和转出，按时间先后排列
+func (x *Indexer) History(account common.Address) []Transfer {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return append([]Transfer(nil), x.history[account]...)
+}
+
+func toTransfer(event *token.Erc20Transfer) Transfer {
+	return Transfer{
+		From:     event.From,
+		To:       event.To,
+		Value:    event.Value,
+		Block:    event.Raw.BlockNumber,
+		LogIndex: event.Raw.Index,
+		TxHash:   event.Raw.TxHash,
+	}
+}
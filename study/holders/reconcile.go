@@ -0,0 +1,146 @@
+package holders
+
+import (
+	"context"
+	"eth-client-study/study/blockref"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// erc20 绑定只包含 IERC20Metadata 中用到的方法，没有 totalSupply，这里单独绑定
+const supplyABI = `[{"inputs":[],"name":"totalSupply","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+func newSupplyContract(address common.Address, backend bind.ContractBackend) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(supplyABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, backend, backend, backend), nil
+}
+
+// Kind 对账得出的代币类型
+type Kind int
+
+const (
+	// Consistent 事件与链上余额、总供应量完全一致
+	Consistent Kind = iota
+	// FeeOnTransfer 收款方的链上余额小于事件中的金额，转账时被扣了手续费但没有为手续费发出 Transfer 事件
+	FeeOnTransfer
+	// Rebasing 链上余额与事件计算的余额之比对所有持有人大致相同，余额会在没有事件的情况下按比例变化
+	Rebasing
+	// Inconsistent 存在不符合上面两种模式的差异，可能是余额被直接修改或回放不完整
+	Inconsistent
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Consistent:
+		return "consistent"
+	case FeeOnTransfer:
+		return "fee-on-transfer"
+	case Rebasing:
+		return "rebasing"
+	case Inconsistent:
+		return "inconsistent"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// RebaseTolerance 判定为 Rebasing 时各持有人余额比值允许的相对偏差
+const RebaseTolerance = 0.001
+
+// Discrepancy 事件计算的余额与链上 BalanceOf 不一致的地址
+type Discrepancy struct {
+	Address common.Address
+	Indexed *big.Int
+	Chain   *big.Int
+}
+
+// Report 对账结果
+type Report struct {
+	Block         uint64
+	Checked       int // 比对过的地址数
+	Discrepancies []Discrepancy
+	IndexedSupply *big.Int
+	ChainSupply   *big.Int
+	Kind          Kind
+}
+
+// Reconcile 在 Synced 区块上把事件计算的余额和总供应量与链上 BalanceOf、totalSupply 比对，并据此判断代币类型
+// 随机抽取 sample 个持有人（<= 0 时检查全部）；应在 Sync 完成后、Watch 之外调用，否则索引中可能已包含 Synced 之后区块的事件
+func (x *Indexer) Reconcile(ctx context.Context, sample int) (*Report, error) {
+	block := x.Synced()
+	x.mu.RLock()
+	holders := make([]Holder, 0, len(x.balances))
+	for account, balance := range x.balances {
+		holders = append(holders, Holder{Address: account, Balance: new(big.Int).Set(balance)})
+	}
+	report := &Report{Block: block, IndexedSupply: new(big.Int).Set(x.totalSupply)}
+	x.mu.RUnlock()
+
+	if sample > 0 && sample < len(holders) {
+		rand.Shuffle(len(holders), func(i, j int) { holders[i], holders[j] = holders[j], holders[i] })
+		holders = holders[:sample]
+	}
+	opts := blockref.Number(block).CallOpts(ctx)
+	var out []interface{}
+	if err := x.supply.Call(opts, &out, "totalSupply"); err != nil {
+		return nil, fmt.Errorf("totalSupply: %w", err)
+	}
+	report.ChainSupply = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	for _, h := range holders {
+		chain, err := x.caller.BalanceOf(opts, h.Address)
+		if err != nil {
+			return nil, fmt.Errorf("balanceOf(%s): %w", h.Address.Hex(), err)
+		}
+		if chain.Cmp(h.Balance) != 0 {
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{Address: h.Address, Indexed: h.Balance, Chain: chain})
+		}
+	}
+	report.Checked = len(holders)
+	report.Kind = classify(report)
+	return report, nil
+}
+
+func classify(r *Report) Kind {
+	supplyDiffers := r.ChainSupply.Cmp(r.IndexedSupply) != 0
+	if len(r.Discrepancies) == 0 {
+		if supplyDiffers {
+			return Inconsistent
+		}
+		return Consistent
+	}
+	// 所有抽查的持有人都不一致且比值相同：余额按比例缩放
+	if len(r.Discrepancies) == r.Checked && r.Checked > 1 {
+		lo, hi := ratio(r.Discrepancies[0]), ratio(r.Discrepancies[0])
+		for _, d := range r.Discrepancies[1:] {
+			q := ratio(d)
+			lo, hi = min(lo, q), max(hi, q)
+		}
+		if lo > 0 && (hi-lo)/lo <= RebaseTolerance {
+			return Rebasing
+		}
+	}
+	// 手续费只会让收款方少收，链上余额不会比事件计算的多；被扣掉的部分要么被销毁，要么转给了没有事件的地址
+	for _, d := range r.Discrepancies {
+		if d.Chain.Cmp(d.Indexed) > 0 {
+			return Inconsistent
+		}
+	}
+	return FeeOnTransfer
+}
+
+func ratio(d Discrepancy) float64 {
+	if d.Indexed.Sign() <= 0 {
+		return 0
+	}
+	q, _ := new(big.Rat).SetFrac(d.Chain, d.Indexed).Float64()
+	return q
+}
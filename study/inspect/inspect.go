@@ -0,0 +1,259 @@
+package inspect
+
+import (
+	"context"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/decoder"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TxInfo 单笔交易的检查结果，金额单位均为 wei
+type TxInfo struct {
+	Index           uint             `json:"index"`
+	Hash            common.Hash      `json:"hash"`
+	Type            uint8            `json:"type"`
+	TypeName        string           `json:"typeName"`
+	From            common.Address   `json:"from"`
+	FromLabel       string           `json:"fromLabel,omitempty"` // 地址簿中的名称，需设置 Inspector.Labels
+	To              *common.Address  `json:"to"`
+	ToLabel         string           `json:"toLabel,omitempty"`         // 接收方或新合约在地址簿中的名称
+	ContractAddress *common.Address  `json:"contractAddress,omitempty"` // 合约创建交易生成的合约地址
+	Nonce           uint64           `json:"nonce"`
+	Value           *big.Int         `json:"value"`
+	Input           hexutil.Bytes    `json:"input"`
+	Method          string           `json:"method,omitempty"` // 解析出的调用函数，需设置 Inspector.Decoder，碰撞时以 | 分隔
+	Status          uint64           `json:"status"`
+	GasLimit        uint64           `json:"gasLimit"`
+	GasUsed         uint64           `json:"gasUsed"`
+	GasPrice        *big.Int         `json:"gasPrice,omitempty"`  // 仅 legacy/2930 交易
+	GasTipCap       *big.Int         `json:"gasTipCap,omitempty"` // 1559 及之后的交易类型
+	GasFeeCap       *big.Int         `json:"gasFeeCap,omitempty"` // 1559 及之后的交易类型
+	EffectiveGas    *big.Int         `json:"effectiveGasPrice"`   // 实际成交的 gas 单价
+	FeeBurned       *big.Int         `json:"feeBurned"`           // baseFee * gasUsed
+	PriorityFeePaid *big.Int         `json:"priorityFeePaid"`     // (effectiveGasPrice - baseFee) * gasUsed，归出块者
+	BlobGas         uint64           `json:"blobGas,omitempty"`   // 4844 交易声明的 blob gas
+	BlobGasFeeCap   *big.Int         `json:"blobGasFeeCap,omitempty"`
+	BlobGasPrice    *big.Int         `json:"blobGasPrice,omitempty"` // 回执中的 blob gas 单价
+	BlobFeeBurned   *big.Int         `json:"blobFeeBurned,omitempty"`
+	BlobHashes      []common.Hash    `json:"blobHashes,omitempty"`
+	AccessList      types.AccessList `json:"accessList,omitempty"`
+	Authorities     []common.Address `json:"authorities,omitempty"` // 7702 授权账户
+	Logs            int              `json:"logs"`
+}
+
+// BlockInfo 区块检查结果
+type BlockInfo struct {
+	Number        uint64         `json:"number"`
+	Hash          common.Hash    `json:"hash"`
+	ParentHash    common.Hash    `json:"parentHash"`
+	Time          uint64         `json:"timestamp"`
+	Miner         common.Address `json:"miner"`
+	MinerLabel    string         `json:"minerLabel,omitempty"`
+	GasLimit      uint64         `json:"gasLimit"`
+	GasUsed       uint64         `json:"gasUsed"`
+	BaseFee       *big.Int       `json:"baseFeePerGas,omitempty"`
+	BlobGasUsed   *uint64        `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas *uint64        `json:"excessBlobGas,omitempty"`
+	TotalBurned   *big.Int       `json:"totalBurned"`
+	TotalPriority *big.Int       `json:"totalPriorityFee"`
+	Transactions  []*TxInfo      `json:"transactions"`
+}
+
+// Labeler 为地址提供可读名称，如地址簿（见 addressbook.Chain）
+type Labeler interface {
+	Label(address common.Address) (string, bool)
+}
+
+// Inspector 区块检查器，支持所有交易类型（legacy、2930、1559、4844、7702）
+type Inspector struct {
+	client  *ethclient.Client
+	chainID *big.Int
+
+	Decoder *decoder.Decoder // 不为空时解析每笔交易的调用数据
+	Labels  Labeler          // 不为空时为发送方、接收方和出块者附上名称
+}
+
+// NewInspector 创建区块检查器
+func NewInspector(ctx context.Context, client *ethclient.Client) (*Inspector, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Inspector{client: client, chainID: chainID}, nil
+}
+
+// InspectBlock 获取 sel 对应的区块（零值为 latest）及其全部回执并逐笔分析
+// 先把标签解析为区块头，再按哈希读取区块和回执，保证两者来自同一个区块
+func (i *Inspector) InspectBlock(ctx context.Context, sel blockref.Selector) (*BlockInfo, error) {
+	head, err := blockref.Header(ctx, i.client, sel)
+	if err != nil {
+		return nil, err
+	}
+	block, err := i.client.BlockByHash(ctx, head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := i.client.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(block.Transactions()) {
+		return nil, fmt.Errorf("block %d has %d transactions but %d receipts", block.NumberU64(), len(block.Transactions()), len(receipts))
+	}
+	header := block.Header()
+	info := &BlockInfo{
+		Number:        header.Number.Uint64(),
+		Hash:          block.Hash(),
+		ParentHash:    header.ParentHash,
+		Time:          header.Time,
+		Miner:         header.Coinbase,
+		GasLimit:      header.GasLimit,
+		GasUsed:       header.GasUsed,
+		BaseFee:       header.BaseFee,
+		BlobGasUsed:   header.BlobGasUsed,
+		ExcessBlobGas: header.ExcessBlobGas,
+		TotalBurned:   new(big.Int),
+		TotalPriority: new(big.Int),
+	}
+	info.MinerLabel = i.label(&info.Miner)
+	for idx, tx := range block.Transactions() {
+		txInfo, err := i.InspectTransaction(tx, receipts[idx], header)
+		if err != nil {
+			return nil, fmt.Errorf("tx %s: %w", tx.Hash().Hex(), err)
+		}
+		info.TotalBurned.Add(info.TotalBurned, txInfo.FeeBurned)
+		if txInfo.BlobFeeBurned != nil {
+			info.TotalBurned.Add(info.TotalBurned, txInfo.BlobFeeBurned)
+		}
+		info.TotalPriority.Add(info.TotalPriority, txInfo.PriorityFeePaid)
+		info.Transactions = append(info.Transactions, txInfo)
+	}
+	return info, nil
+}
+
+// InspectTransaction 结合回执和所在区块头分析单笔交易
+func (i *Inspector) InspectTransaction(tx *types.Transaction, receipt *types.Receipt, header *types.Header) (*TxInfo, error) {
+	// LatestSignerForChainID 能处理所有已激活的交易类型，EIP155Signer 只支持 legacy 交易
+	signer := types.LatestSignerForChainID(i.chainID)
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("recover sender: %w", err)
+	}
+	info := &TxInfo{
+		Index:      receipt.TransactionIndex,
+		Hash:       tx.Hash(),
+		Type:       tx.Type(),
+		TypeName:   TypeName(tx.Type()),
+		From:       from,
+		To:         tx.To(),
+		Nonce:      tx.Nonce(),
+		Value:      tx.Value(),
+		Input:      tx.Data(),
+		Status:     receipt.Status,
+		GasLimit:   tx.Gas(),
+		GasUsed:    receipt.GasUsed,
+		AccessList: tx.AccessList(),
+		Logs:       len(receipt.Logs),
+	}
+	if tx.To() == nil {
+		// 合约创建交易：优先使用回执中的地址，否则按 sender+nonce 推算
+		contract := receipt.ContractAddress
+		if contract == (common.Address{}) {
+			contract = crypto.CreateAddress(from, tx.Nonce())
+		}
+		info.ContractAddress = &contract
+	}
+	info.FromLabel = i.label(&info.From)
+	info.ToLabel = i.label(info.To)
+	if info.To == nil {
+		info.ToLabel = i.label(info.ContractAddress)
+	}
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		info.GasPrice = tx.GasPrice()
+	default:
+		info.GasTipCap = tx.GasTipCap()
+		info.GasFeeCap = tx.GasFeeCap()
+	}
+	if tx.Type() == types.SetCodeTxType {
+		info.Authorities = tx.SetCodeAuthorities()
+	}
+
+	// 实际 gas 单价：节点返回的回执中带有 effectiveGasPrice，缺失时自行计算
+	info.EffectiveGas = receipt.EffectiveGasPrice
+	if info.EffectiveGas == nil {
+		info.EffectiveGas = tx.GasPrice()
+		if header.BaseFee != nil {
+			tip, err := tx.EffectiveGasTip(header.BaseFee)
+			if err != nil {
+				return nil, err
+			}
+			info.EffectiveGas = tip.Add(tip, header.BaseFee)
+		}
+	}
+	gasUsed := new(big.Int).SetUint64(receipt.GasUsed)
+	info.FeeBurned = new(big.Int)
+	priorityPrice := new(big.Int).Set(info.EffectiveGas)
+	if header.BaseFee != nil {
+		info.FeeBurned.Mul(header.BaseFee, gasUsed)
+		priorityPrice.Sub(priorityPrice, header.BaseFee)
+	}
+	info.PriorityFeePaid = priorityPrice.Mul(priorityPrice, gasUsed)
+
+	if tx.Type() == types.BlobTxType {
+		info.BlobGas = tx.BlobGas()
+		info.BlobGasFeeCap = tx.BlobGasFeeCap()
+		info.BlobHashes = tx.BlobHashes()
+		info.BlobGasPrice = receipt.BlobGasPrice
+		if receipt.BlobGasPrice != nil {
+			info.BlobFeeBurned = new(big.Int).Mul(receipt.BlobGasPrice, new(big.Int).SetUint64(receipt.BlobGasUsed))
+		}
+	}
+
+	if i.Decoder != nil && len(tx.Data()) >= 4 {
+		if calls, err := i.Decoder.Decode(tx.To(), tx.Data()); err == nil {
+			// 选择器碰撞时保留所有能正确解析的候选
+			methods := make([]string, len(calls))
+			for j, call := range calls {
+				methods[j] = call.String()
+			}
+			info.Method = strings.Join(methods, " | ")
+		}
+	}
+	return info, nil
+}
+
+// label 地址的名称，未设置 Labels 或地址不在其中时返回空字符串
+func (i *Inspector) label(address *common.Address) string {
+	if i.Labels == nil || address == nil {
+		return ""
+	}
+	name, _ := i.Labels.Label(*address)
+	return name
+}
+
+// TypeName 返回交易类型的可读名称
+func TypeName(txType uint8) string {
+	switch txType {
+	case types.LegacyTxType:
+		return "legacy"
+	case types.AccessListTxType:
+		return "access-list (EIP-2930)"
+	case types.DynamicFeeTxType:
+		return "dynamic-fee (EIP-1559)"
+	case types.BlobTxType:
+		return "blob (EIP-4844)"
+	case types.SetCodeTxType:
+		return "set-code (EIP-7702)"
+	}
+	return fmt.Sprintf("unknown (0x%02x)", txType)
+}
@@ -0,0 +1,176 @@
+package inspect
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// 支持的输出格式
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Write 按指定格式输出区块检查结果
+func Write(w io.Writer, info *BlockInfo, format string) error {
+	switch format {
+	case FormatText, "":
+		return WriteText(w, info)
+	case FormatJSON:
+		return WriteJSON(w, info)
+	case FormatCSV:
+		return WriteCSV(w, info)
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// WriteJSON 以缩进的 JSON 输出
+func WriteJSON(w io.Writer, info *BlockInfo) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(info)
+}
+
+// csvHeader CSV 每行对应一笔交易
+var csvHeader = []string{
+	"block", "index", "hash", "type", "from", "to", "contractAddress", "nonce", "value",
+	"status", "gasLimit", "gasUsed", "gasPrice", "gasTipCap", "gasFeeCap", "effectiveGasPrice",
+	"feeBurned", "priorityFeePaid", "blobGas", "blobGasPrice", "blobFeeBurned", "accessListSize", "logs", "method",
+	"fromLabel", "toLabel",
+}
+
+// WriteCSV 每笔交易输出一行，适合导入表格分析
+func WriteCSV(w io.Writer, info *BlockInfo) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, tx := range info.Transactions {
+		record := []string{
+			strconv.FormatUint(info.Number, 10),
+			strconv.FormatUint(uint64(tx.Index), 10),
+			tx.Hash.Hex(),
+			strconv.Itoa(int(tx.Type)),
+			tx.From.Hex(),
+			addressString(tx.To),
+			addressString(tx.ContractAddress),
+			strconv.FormatUint(tx.Nonce, 10),
+			bigString(tx.Value),
+			strconv.FormatUint(tx.Status, 10),
+			strconv.FormatUint(tx.GasLimit, 10),
+			strconv.FormatUint(tx.GasUsed, 10),
+			bigString(tx.GasPrice),
+			bigString(tx.GasTipCap),
+			bigString(tx.GasFeeCap),
+			bigString(tx.EffectiveGas),
+			bigString(tx.FeeBurned),
+			bigString(tx.PriorityFeePaid),
+			strconv.FormatUint(tx.BlobGas, 10),
+			bigString(tx.BlobGasPrice),
+			bigString(tx.BlobFeeBurned),
+			strconv.Itoa(len(tx.AccessList)),
+			strconv.Itoa(tx.Logs),
+			tx.Method,
+			tx.FromLabel,
+			tx.ToLabel,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteText 输出便于阅读的文本
+func WriteText(w io.Writer, info *BlockInfo) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "区块号：%d\n", info.Number)
+	fmt.Fprintf(&b, "区块Hash：%s\n", info.Hash.Hex())
+	fmt.Fprintf(&b, "父区块Hash：%s\n", info.ParentHash.Hex())
+	fmt.Fprintf(&b, "时间戳：%s\n", time.Unix(int64(info.Time), 0).UTC().Format("2006-01-02 15:04:05 UTC"))
+	fmt.Fprintf(&b, "出块者：%s\n", labeled(info.Miner.Hex(), info.MinerLabel))
+	fmt.Fprintf(&b, "Gas使用/限制：%d / %d\n", info.GasUsed, info.GasLimit)
+	fmt.Fprintf(&b, "BaseFee：%s wei\n", bigString(info.BaseFee))
+	if info.BlobGasUsed != nil {
+		fmt.Fprintf(&b, "BlobGas使用：%d\n", *info.BlobGasUsed)
+	}
+	if info.ExcessBlobGas != nil {
+		fmt.Fprintf(&b, "ExcessBlobGas：%d\n", *info.ExcessBlobGas)
+	}
+	fmt.Fprintf(&b, "销毁手续费合计：%s wei\n", info.TotalBurned)
+	fmt.Fprintf(&b, "优先费合计：%s wei\n", info.TotalPriority)
+	fmt.Fprintf(&b, "交易数量：%d\n", len(info.Transactions))
+	for _, tx := range info.Transactions {
+		fmt.Fprintf(&b, "\n---------- 交易 #%d ----------\n", tx.Index)
+		fmt.Fprintf(&b, "交易哈希值：%s\n", tx.Hash.Hex())
+		fmt.Fprintf(&b, "交易类型：%s\n", tx.TypeName)
+		fmt.Fprintf(&b, "发送方地址：%s\n", labeled(tx.From.Hex(), tx.FromLabel))
+		if tx.To != nil {
+			fmt.Fprintf(&b, "接收方地址：%s\n", labeled(tx.To.Hex(), tx.ToLabel))
+		} else {
+			fmt.Fprintf(&b, "接收方地址：（合约创建）%s\n", labeled(addressString(tx.ContractAddress), tx.ToLabel))
+		}
+		fmt.Fprintf(&b, "Nonce值：%d\n", tx.Nonce)
+		fmt.Fprintf(&b, "交易金额：%s wei\n", tx.Value)
+		if tx.Method != "" {
+			fmt.Fprintf(&b, "调用函数：%s\n", tx.Method)
+		}
+		fmt.Fprintf(&b, "交易状态：%d\n", tx.Status)
+		fmt.Fprintf(&b, "Gas使用/限制：%d / %d\n", tx.GasUsed, tx.GasLimit)
+		if tx.GasPrice != nil {
+			fmt.Fprintf(&b, "Gas价格：%s wei\n", tx.GasPrice)
+		} else {
+			fmt.Fprintf(&b, "MaxPriorityFee/MaxFee：%s / %s wei\n", tx.GasTipCap, tx.GasFeeCap)
+		}
+		fmt.Fprintf(&b, "实际Gas价格：%s wei\n", tx.EffectiveGas)
+		fmt.Fprintf(&b, "销毁手续费：%s wei\n", tx.FeeBurned)
+		fmt.Fprintf(&b, "优先费：%s wei\n", tx.PriorityFeePaid)
+		if tx.BlobGas > 0 {
+			fmt.Fprintf(&b, "BlobGas：%d，BlobGas价格：%s wei，Blob销毁：%s wei\n", tx.BlobGas, bigString(tx.BlobGasPrice), bigString(tx.BlobFeeBurned))
+			for _, hash := range tx.BlobHashes {
+				fmt.Fprintf(&b, "  BlobHash：%s\n", hash.Hex())
+			}
+		}
+		for _, tuple := range tx.AccessList {
+			fmt.Fprintf(&b, "访问列表：%s（%d 个存储槽）\n", tuple.Address.Hex(), len(tuple.StorageKeys))
+		}
+		for _, authority := range tx.Authorities {
+			fmt.Fprintf(&b, "7702授权账户：%s\n", authority.Hex())
+		}
+		fmt.Fprintf(&b, "事件日志数：%d\n", tx.Logs)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labeled 有名称时在地址后附上名称，如 "0x183A...bC4 (store)"
+func labeled(address, label string) string {
+	if label == "" {
+		return address
+	}
+	return address + " (" + label + ")"
+}
+
+func addressString(address *common.Address) string {
+	if address == nil {
+		return ""
+	}
+	return address.Hex()
+}
+
+func bigString(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
@@ -0,0 +1,59 @@
+// inspect_block.go - 区块检查器
+// 支持所有交易类型，输出合约创建地址、实际 gas 价格、销毁手续费、优先费、blob 字段和访问列表
+// 地址簿（study/addressbook.json）中的地址会附上名称，其中合约的 ABI 用于解析调用数据
+// 用法：
+//
+//	go run study/inspect_block.go -block 5671744 -format json
+//	go run study/inspect_block.go -block finalized
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/inspect"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	blockFlag := flag.String("block", "5671744", "区块：latest、safe、finalized、区块号或区块哈希（末尾加 ! 要求在主链上），为空时使用 BLOCK_TAG")
+	formatFlag := flag.String("format", inspect.FormatText, "输出格式：text、json、csv")
+	decodeFlag := flag.Bool("decode", true, "是否解析交易调用数据")
+	flag.Parse()
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	inspector, err := inspect.NewInspector(context.Background(), client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 地址簿中的名称会标注在发送方、接收方和出块者后面
+	book := addressbook.MustOpen(context.Background(), client)
+	inspector.Labels = book
+	if *decodeFlag {
+		// 与 query_tx.go 相同：地址簿中的 ABI 优先，SIGNATURE_FILE 可扩展签名库
+		if inspector.Decoder, err = book.NewDecoder(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	sel, err := blockref.ParseOrDefault(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	info, err := inspector.InspectBlock(context.Background(), sel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := inspect.Write(os.Stdout, info, *formatFlag); err != nil {
+		log.Fatal(err)
+	}
+}
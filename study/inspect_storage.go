@@ -0,0 +1,78 @@
+// inspect_storage.go - 按存储布局直接读取合约存储槽
+// 不依赖 getter：根据 solc storageLayout（或手写的同格式 JSON）计算槽位后用 eth_getStorageAt 读取
+// 用法：
+//
+//	go run study/inspect_storage.go
+//	go run study/inspect_storage.go -path "items[0x01]" -block finalized
+//	go run study/inspect_storage.go -contract 0x... -layout build/Token.json -path "balances[0x...]" -path "owner"
+//
+// 生成布局：solc --storage-layout store.sol 或在 standard JSON 的 outputSelection 中加入 storageLayout
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/storage"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// pathList 可重复的 -path 参数
+type pathList []string
+
+func (p *pathList) String() string     { return strings.Join(*p, ",") }
+func (p *pathList) Set(v string) error { *p = append(*p, v); return nil }
+
+func main() {
+	contractFlag := flag.String("contract", "store", "合约地址或地址簿名称")
+	layoutFlag := flag.String("layout", "", "storageLayout JSON 文件，为空时使用 Store 合约的布局")
+	blockFlag := flag.String("block", "", "区块：latest、safe、finalized、区块号或区块哈希，为空时使用 BLOCK_TAG（默认 latest）")
+	var paths pathList
+	flag.Var(&paths, "path", "要读取的变量路径，例如 version、items[0x01]、users[3].name，可重复；为空时读取所有状态变量")
+	flag.Parse()
+
+	layout := storage.StoreLayout()
+	if *layoutFlag != "" {
+		var err error
+		if layout, err = storage.LoadLayout(*layoutFlag); err != nil {
+			log.Fatal(err)
+		}
+	}
+	sel, err := blockref.ParseOrDefault(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := rpc.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	book := addressbook.MustOpen(ctx, ethclient.NewClient(client))
+	inspector := storage.NewInspector(blockref.NewReader(client), book.MustResolve("-contract", *contractFlag), layout)
+	if len(paths) == 0 {
+		values, err := inspector.ReadAll(ctx, sel)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, value := range values {
+			fmt.Println(value)
+		}
+		return
+	}
+	for _, path := range paths {
+		value, err := inspector.Read(ctx, path, sel)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(value)
+	}
+}
@@ -2,11 +2,12 @@ package main
 
 import (
 	"context"
+	"eth-client-study/study/addressbook"
 	"eth-client-study/study/store"
+	"eth-client-study/study/storekv"
 	"log"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -16,9 +17,10 @@ func main() {
 		log.Fatal(err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 
-	contractAddr := "0x183AdfEe585d04Db1Ab151840D6399009beC2bC4"
-	storeContract, err := store.NewStoreFilterer(common.HexToAddress(contractAddr), client)
+	contractAddr := "store" // 地址簿中的名称，也可以直接写地址
+	storeContract, err := store.NewStoreFilterer(book.MustResolve("合约地址", contractAddr), client)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -42,8 +44,9 @@ func main() {
 			log.Printf("收到ItemSet事件:")
 			log.Printf("  交易哈希: %s", itemSet.Raw.TxHash.Hex())
 			log.Printf("  区块号: %d", itemSet.Raw.BlockNumber)
-			log.Printf("  Key: %s", string(itemSet.Key[:]))
-			log.Printf("  Value: %s", string(itemSet.Value[:]))
+			// 按字符串解码并去掉补齐的 0 字节，不是字符串时显示十六进制
+			log.Printf("  Key: %s", storekv.Display(storekv.String, itemSet.Key))
+			log.Printf("  Value: %s", storekv.Display(storekv.String, itemSet.Value))
 		case err := <-sub.Err():
 			log.Fatal("订阅错误:", err)
 		}
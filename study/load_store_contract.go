@@ -2,18 +2,20 @@ package main
 
 import (
 	"context"
+	"eth-client-study/study/addressbook"
 	"eth-client-study/study/store"
+	"eth-client-study/study/storekv"
 	"eth-client-study/utils"
 	"log"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-const contractAddress = "0x183AdfEe585d04Db1Ab151840D6399009beC2bC4"
+// contractAddress Store 合约在地址簿中的名称，也可以直接写地址
+const contractAddress = "store"
 
 // 加载store合约
 func main() {
@@ -23,8 +25,9 @@ func main() {
 		log.Fatal(err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 
-	newStore, err := store.NewStore(common.HexToAddress(contractAddress), client)
+	newStore, err := store.NewStore(book.MustResolve("合约地址", contractAddress), client)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -52,6 +55,6 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	log.Println("获取的key:", items)
+	log.Println("获取的key:", storekv.Display(storekv.Hex, items))
 
 }
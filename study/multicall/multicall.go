@@ -0,0 +1,342 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/multicall3"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultAddress Multicall3 在主网及各测试网（含 Sepolia）上的统一部署地址
+var DefaultAddress = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// 默认的分批限制
+const (
+	DefaultMaxCalls        = 500
+	DefaultMaxCalldataSize = 128 * 1024
+)
+
+var (
+	// ErrCallFailed 子调用执行失败（revert 或目标不是合约）
+	ErrCallFailed = errors.New("multicall: call failed")
+	// errRecorded 记录阶段返回给绑定代码的占位错误
+	errRecorded = errors.New("multicall: call recorded")
+)
+
+// Call 一个待聚合的底层调用
+type Call struct {
+	Target       common.Address
+	AllowFailure bool // 为 true 时该调用失败不会导致 Execute 返回错误
+	CallData     []byte
+}
+
+// Result 单个调用的执行结果，Execute 完成后填充
+type Result struct {
+	Success    bool
+	ReturnData []byte
+	Err        error // 调用失败或绑定代码解析返回值失败时的错误
+}
+
+type entry struct {
+	call   Call
+	fn     func(caller bind.ContractCaller) error // 通过 Add 加入的绑定调用，AddCall 加入时为 nil
+	result *Result
+}
+
+// Multicall 把多个只读调用合并为一次 Multicall3.aggregate3 的 eth_call
+//
+// 除了原始 calldata，还可以直接批量执行 abigen 生成的绑定方法
+// （Erc20Caller、StoreCaller、CounterCaller 等），见 Add
+type Multicall struct {
+	caller  bind.ContractCaller
+	address common.Address
+	abi     *abi.ABI
+	entries []*entry
+
+	MaxCalls        int    // 每批最多包含的调用数
+	MaxCalldataSize int    // 每批 calldata 的最大字节数
+	GasLimit        uint64 // 每次 eth_call 的 gas 上限，0 表示使用节点默认值
+}
+
+// New 创建一个使用 address 处 Multicall3 合约的聚合器
+func New(caller bind.ContractCaller, address common.Address) (*Multicall, error) {
+	parsed, err := multicall3.Multicall3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Multicall{
+		caller:          caller,
+		address:         address,
+		abi:             parsed,
+		MaxCalls:        DefaultMaxCalls,
+		MaxCalldataSize: DefaultMaxCalldataSize,
+	}, nil
+}
+
+// Address 返回使用的 Multicall3 合约地址
+func (m *Multicall) Address() common.Address {
+	return m.address
+}
+
+// Len 返回已加入的调用数
+func (m *Multicall) Len() int {
+	return len(m.entries)
+}
+
+// AddCall 加入一个原始调用，返回的 Result 在 Execute 之后可用
+func (m *Multicall) AddCall(call Call) *Result {
+	e := &entry{call: call, result: new(Result)}
+	m.entries = append(m.entries, e)
+	return e.result
+}
+
+// Add 加入一个通过合约绑定发起的调用
+//
+// fn 必须且只能通过传入的 caller 发起一次调用，例如：
+//
+//	var balance *big.Int
+//	mc.Add(false, func(caller bind.ContractCaller) error {
+//		instance, err := token.NewErc20Caller(tokenAddress, caller)
+//		if err != nil {
+//			return err
+//		}
+//		balance, err = instance.BalanceOf(nil, account)
+//		return err
+//	})
+//
+// Add 会先执行一次 fn 以记录目标地址和 calldata；Execute 拿到结果后再次执行 fn，
+// 由绑定代码完成返回值解析。fn 中 CallOpts 的区块号会被忽略，统一使用 Execute 的选择器
+func (m *Multicall) Add(allowFailure bool, fn func(caller bind.ContractCaller) error) (*Result, error) {
+	rec := new(recorder)
+	if err := fn(rec); err != nil && !errors.Is(err, errRecorded) {
+		return nil, err
+	}
+	if rec.calls != 1 {
+		return nil, fmt.Errorf("multicall: expected exactly one contract call, got %d", rec.calls)
+	}
+	e := &entry{
+		call:   Call{Target: rec.target, AllowFailure: allowFailure, CallData: rec.data},
+		fn:     fn,
+		result: new(Result),
+	}
+	m.entries = append(m.entries, e)
+	return e.result, nil
+}
+
+// Execute 在 sel 对应的区块（零值为 latest）上按 MaxCalls/MaxCalldataSize 分批执行全部调用
+// 分成多批且 sel 为 latest、safe 等标签时，先把 sel 解析为区块哈希，所有批次读取同一区块（见 pin）；
+// 某一批因 gas 或请求大小超限失败时会自动对半拆分后重试，其他 eth_call 错误直接返回；
+// 任一 AllowFailure 为 false 的调用失败时返回 ErrCallFailed
+func (m *Multicall) Execute(ctx context.Context, sel blockref.Selector) error {
+	batches := m.batches()
+	if len(batches) > 1 {
+		var err error
+		if sel, err = m.pin(ctx, sel); err != nil {
+			return err
+		}
+	}
+	caller := blockref.Bind(m.caller, sel)
+	for _, batch := range batches {
+		if err := m.run(ctx, caller, batch); err != nil {
+			return err
+		}
+	}
+	for i, e := range m.entries {
+		if e.fn != nil && e.result.Success {
+			e.result.Err = e.fn(&replayer{result: e.result})
+		}
+		if e.result.Err != nil && !e.call.AllowFailure {
+			return fmt.Errorf("call %d to %s: %w", i, e.call.Target.Hex(), e.result.Err)
+		}
+	}
+	return nil
+}
+
+// pinner 能读取区块头并按区块哈希调用的数据源，*ethclient.Client 和模拟链的客户端都满足
+type pinner interface {
+	blockref.HeaderByNumberReader
+	bind.BlockHashContractCaller
+}
+
+// pin 把 latest、safe、finalized 等随链头变化的选择器解析为当前对应区块的哈希
+// pending 没有可按哈希读取的区块，caller 不满足 pinner 时也无法固定，这两种情况原样返回，各批次可能读到不同区块
+func (m *Multicall) pin(ctx context.Context, sel blockref.Selector) (blockref.Selector, error) {
+	if !sel.IsZero() && !sel.IsTag() {
+		return sel, nil
+	}
+	if number, ok := sel.BlockNumber(); ok && number == rpc.PendingBlockNumber {
+		return sel, nil
+	}
+	reader, ok := m.caller.(pinner)
+	if !ok {
+		return sel, nil
+	}
+	header, err := blockref.Header(ctx, reader, sel)
+	if err != nil {
+		return sel, err
+	}
+	return blockref.Hash(header.Hash(), false), nil
+}
+
+// batches 按调用数和 calldata 大小切分
+func (m *Multicall) batches() [][]*entry {
+	var (
+		batches [][]*entry
+		current []*entry
+		size    int
+	)
+	for _, e := range m.entries {
+		callSize := len(e.call.CallData) + 128 // 每个 Call3 元组的编码开销
+		if len(current) > 0 && ((m.MaxCalls > 0 && len(current) >= m.MaxCalls) ||
+			(m.MaxCalldataSize > 0 && size+callSize > m.MaxCalldataSize)) {
+			batches = append(batches, current)
+			current, size = nil, 0
+		}
+		current = append(current, e)
+		size += callSize
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// run 执行一批调用；因 gas 或请求大小超限失败时对半拆分重试，直到只剩单个调用
+// 其他错误（节点不可用、限流、没有 Multicall3 合约等）拆分也无济于事，直接返回
+func (m *Multicall) run(ctx context.Context, caller bind.ContractCaller, batch []*entry) error {
+	results, err := m.aggregate(ctx, caller, batch)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !splittable(err) {
+			return err
+		}
+		if len(batch) == 1 {
+			batch[0].result.Err = fmt.Errorf("%w: %v", ErrCallFailed, err)
+			return nil
+		}
+		half := len(batch) / 2
+		if err := m.run(ctx, caller, batch[:half]); err != nil {
+			return err
+		}
+		return m.run(ctx, caller, batch[half:])
+	}
+	for i, e := range batch {
+		e.result.Success = results[i].Success
+		e.result.ReturnData = results[i].ReturnData
+		if !results[i].Success {
+			e.result.Err = callError(results[i].ReturnData)
+		}
+	}
+	return nil
+}
+
+// splittable 错误是否由 gas 或请求/响应大小超限引起，拆小批次后可能成功
+func splittable(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestEntityTooLarge {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{
+		"out of gas",
+		"gas required exceeds",
+		"exceeds block gas limit",
+		"gas limit reached",
+		"request entity too large",
+		"request too large",
+		"response size exceeded",
+		"response is too big",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregate 发起一次 aggregate3 eth_call
+// 合约层面所有调用都允许失败，AllowFailure 的语义在 Execute 中处理，
+// 这样单个 revert 不会拖垮整批调用
+func (m *Multicall) aggregate(ctx context.Context, caller bind.ContractCaller, batch []*entry) ([]multicall3.Multicall3Result, error) {
+	calls := make([]multicall3.Multicall3Call3, len(batch))
+	for i, e := range batch {
+		calls[i] = multicall3.Multicall3Call3{Target: e.call.Target, AllowFailure: true, CallData: e.call.CallData}
+	}
+	input, err := m.abi.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, err
+	}
+	msg := ethereum.CallMsg{To: &m.address, Gas: m.GasLimit, Data: input}
+	output, err := caller.CallContract(ctx, msg, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("no Multicall3 contract at %s", m.address.Hex())
+	}
+	var results []multicall3.Multicall3Result
+	if err := m.abi.UnpackIntoInterface(&results, "aggregate3", output); err != nil {
+		return nil, err
+	}
+	if len(results) != len(batch) {
+		return nil, fmt.Errorf("multicall: expected %d results, got %d", len(batch), len(results))
+	}
+	return results, nil
+}
+
+// callError 把失败调用的返回数据转换为错误，能解析出 revert 原因时附带原因
+func callError(returnData []byte) error {
+	if reason, err := abi.UnpackRevert(returnData); err == nil {
+		return fmt.Errorf("%w: execution reverted: %s", ErrCallFailed, reason)
+	}
+	if len(returnData) > 0 {
+		return fmt.Errorf("%w: execution reverted: %x", ErrCallFailed, returnData)
+	}
+	return fmt.Errorf("%w: execution reverted", ErrCallFailed)
+}
+
+// recorder 记录绑定代码发起的调用而不真正执行
+type recorder struct {
+	calls  int
+	target common.Address
+	data   []byte
+}
+
+func (r *recorder) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	r.calls++
+	if msg.To != nil {
+		r.target = *msg.To
+	}
+	r.data = common.CopyBytes(msg.Data)
+	return nil, errRecorded
+}
+
+func (r *recorder) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, errRecorded
+}
+
+// replayer 把聚合调用的结果交还给绑定代码解析
+type replayer struct {
+	result *Result
+}
+
+func (r *replayer) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return r.result.ReturnData, nil
+}
+
+// CodeAt 只有返回数据为空时才会被绑定代码调用，此时说明目标地址不是合约
+func (r *replayer) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,126 @@
+// multicall_simulated.go - 在离线模拟链上演示 Multicall3 批量读取
+// 部署 Store、Counter、Multicall3 后，把多个绑定调用合并为一次 eth_call
+package main
+
+import (
+	"context"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/multicall"
+	"eth-client-study/study/simulated"
+	"eth-client-study/study/store"
+	"eth-client-study/task01/counter"
+	"eth-client-study/utils"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func main() {
+	harness, err := simulated.NewHarness(1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer harness.Close()
+
+	multicallAddress, _, err := harness.DeployMulticall3()
+	if err != nil {
+		log.Fatal("部署Multicall3失败：", err)
+	}
+	storeAddress, storeContract, err := harness.DeployStore("1.0")
+	if err != nil {
+		log.Fatal("部署Store失败：", err)
+	}
+	counterAddress, counterContract, err := harness.DeployCounter()
+	if err != nil {
+		log.Fatal("部署Counter失败：", err)
+	}
+
+	// 写入一些数据
+	opts, err := harness.TransactOpts(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var key, value [32]byte
+	copy(key[:], "demo_key")
+	copy(value[:], "demo_value")
+	tx, err := storeContract.SetItem(opts, key, value)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := harness.WaitMined(tx); err != nil {
+		log.Fatal(err)
+	}
+	tx, err = counterContract.Increment(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := harness.WaitMined(tx); err != nil {
+		log.Fatal(err)
+	}
+
+	mc, err := multicall.New(harness.Client, multicallAddress)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 为了演示自动分批，每批最多 2 个调用
+	mc.MaxCalls = 2
+
+	var (
+		version string
+		item    [32]byte
+		count   *big.Int
+	)
+	if _, err := mc.Add(false, func(caller bind.ContractCaller) error {
+		instance, err := store.NewStoreCaller(storeAddress, caller)
+		if err != nil {
+			return err
+		}
+		version, err = instance.Version(nil)
+		return err
+	}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := mc.Add(false, func(caller bind.ContractCaller) error {
+		instance, err := store.NewStoreCaller(storeAddress, caller)
+		if err != nil {
+			return err
+		}
+		item, err = instance.Items(nil, key)
+		return err
+	}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := mc.Add(false, func(caller bind.ContractCaller) error {
+		instance, err := counter.NewCounterCaller(counterAddress, caller)
+		if err != nil {
+			return err
+		}
+		count, err = instance.GetCount(nil)
+		return err
+	}); err != nil {
+		log.Fatal(err)
+	}
+	// 允许失败的调用：目标地址不是合约，失败不会影响其他调用
+	missing, err := mc.Add(true, func(caller bind.ContractCaller) error {
+		instance, err := counter.NewCounterCaller(utils.MustAddress("合约地址", "0x000000000000000000000000000000000000dEaD"), caller)
+		if err != nil {
+			return err
+		}
+		_, err = instance.GetCount(nil)
+		return err
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := mc.Execute(context.Background(), blockref.Latest); err != nil {
+		log.Fatal("批量调用失败：", err)
+	}
+	fmt.Println("Store版本：", version)
+	fmt.Println("Store items[demo_key]：", string(common.TrimRightZeroes(item[:])))
+	fmt.Println("Counter计数：", count)
+	fmt.Println("允许失败的调用结果：", missing.Err)
+}
@@ -0,0 +1,159 @@
+package nft
+
+import (
+	"context"
+	"eth-client-study/study/erc1155"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MultiToken 对 ERC1155 合约的封装
+type MultiToken struct {
+	Address common.Address
+
+	contract *erc1155.Erc1155
+	abi      *abi.ABI
+	backend  bind.ContractBackend
+}
+
+// NewMultiToken 创建 ERC1155 合约封装，不发起请求；不确定合约类型时先用 Classify 检测
+func NewMultiToken(address common.Address, backend bind.ContractBackend) (*MultiToken, error) {
+	contract, err := erc1155.NewErc1155(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := erc1155.Erc1155MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &MultiToken{Address: address, contract: contract, abi: parsed, backend: backend}, nil
+}
+
+// Contract 底层的 abigen 绑定
+func (m *MultiToken) Contract() *erc1155.Erc1155 {
+	return m.contract
+}
+
+// BalanceOf account 持有的 id 代币数量
+func (m *MultiToken) BalanceOf(ctx context.Context, account common.Address, id *big.Int) (*big.Int, error) {
+	return m.contract.BalanceOf(&bind.CallOpts{Context: ctx}, account, id)
+}
+
+// BalanceOfBatch 一次查询多个 (account, id) 的余额，accounts 与 ids 一一对应
+func (m *MultiToken) BalanceOfBatch(ctx context.Context, accounts []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	if len(accounts) != len(ids) {
+		return nil, fmt.Errorf("%d accounts but %d ids", len(accounts), len(ids))
+	}
+	balances, err := m.contract.BalanceOfBatch(&bind.CallOpts{Context: ctx}, accounts, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(balances) != len(ids) {
+		return nil, fmt.Errorf("balanceOfBatch returned %d balances for %d ids", len(balances), len(ids))
+	}
+	return balances, nil
+}
+
+// URI id 的元数据 URI，其中的 "{id}" 已按 ERC1155 的约定替换
+func (m *MultiToken) URI(ctx context.Context, id *big.Int) (string, error) {
+	uri, err := m.contract.Uri(&bind.CallOpts{Context: ctx}, id)
+	if err != nil {
+		return "", err
+	}
+	return ExpandURI(uri, id), nil
+}
+
+// ExpandURI 把 URI 中的 "{id}" 替换为 64 位、不带 0x 前缀的小写十六进制 id
+func ExpandURI(uri string, id *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", id))
+}
+
+// CanTransfer 检查 operator 能否从 from 转出 ids 对应的 values：operator 是 from 本人或其操作员，且每个 id 的余额足够
+// ids 中有重复时按合计数量检查
+func (m *MultiToken) CanTransfer(ctx context.Context, operator, from common.Address, ids, values []*big.Int) error {
+	if len(ids) != len(values) {
+		return fmt.Errorf("%d ids but %d values", len(ids), len(values))
+	}
+	if operator != from {
+		ok, err := m.contract.IsApprovedForAll(&bind.CallOpts{Context: ctx}, from, operator)
+		if err != nil {
+			return fmt.Errorf("isApprovedForAll: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s for %s", ErrNotApproved, operator.Hex(), from.Hex())
+		}
+	}
+	var (
+		unique []*big.Int
+		totals = make(map[string]*big.Int)
+	)
+	for i, id := range ids {
+		total, ok := totals[id.String()]
+		if !ok {
+			total = new(big.Int)
+			totals[id.String()] = total
+			unique = append(unique, id)
+		}
+		total.Add(total, values[i])
+	}
+	accounts := make([]common.Address, len(unique))
+	for i := range accounts {
+		accounts[i] = from
+	}
+	balances, err := m.BalanceOfBatch(ctx, accounts, unique)
+	if err != nil {
+		return fmt.Errorf("balanceOfBatch: %w", err)
+	}
+	for i, id := range unique {
+		if want := totals[id.String()]; balances[i].Cmp(want) < 0 {
+			return fmt.Errorf("%w: id %s balance %s, want %s", ErrNotOwner, id, balances[i], want)
+		}
+	}
+	return nil
+}
+
+// SafeBatchTransfer 检查授权和余额并模拟执行后，以 opts.From 的身份发送 safeBatchTransferFrom
+// to 为合约时必须实现 onERC1155BatchReceived，否则模拟执行会回滚
+func (m *MultiToken) SafeBatchTransfer(opts *bind.TransactOpts, from, to common.Address, ids, values []*big.Int, data []byte) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := m.CanTransfer(ctx, opts.From, from, ids, values); err != nil {
+		return nil, err
+	}
+	input, err := m.abi.Pack("safeBatchTransferFrom", from, to, ids, values, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.backend.CallContract(ctx, ethereum.CallMsg{From: opts.From, To: &m.Address, Data: input}, nil); err != nil {
+		return nil, fmt.Errorf("simulate safeBatchTransferFrom: %w", err)
+	}
+	return m.contract.SafeBatchTransferFrom(opts, from, to, ids, values, data)
+}
+
+// SafeTransfer 转移单个 id，检查和模拟规则与 SafeBatchTransfer 相同
+func (m *MultiToken) SafeTransfer(opts *bind.TransactOpts, from, to common.Address, id, value *big.Int, data []byte) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := m.CanTransfer(ctx, opts.From, from, []*big.Int{id}, []*big.Int{value}); err != nil {
+		return nil, err
+	}
+	input, err := m.abi.Pack("safeTransferFrom", from, to, id, value, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.backend.CallContract(ctx, ethereum.CallMsg{From: opts.From, To: &m.Address, Data: input}, nil); err != nil {
+		return nil, fmt.Errorf("simulate safeTransferFrom: %w", err)
+	}
+	return m.contract.SafeTransferFrom(opts, from, to, id, value, data)
+}
@@ -0,0 +1,192 @@
+package nft
+
+import (
+	"context"
+	"eth-client-study/study/erc721"
+	"eth-client-study/study/tokens"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SupportsInterfaceGas EIP-165 规定查询 supportsInterface 时使用的 gas 上限
+const SupportsInterfaceGas = 30000
+
+// 常见接口的 EIP-165 interfaceId（接口中全部函数选择器的异或）
+var (
+	InterfaceERC165             = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	InterfaceERC721             = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceERC721Metadata     = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	InterfaceERC721Enumerable   = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+	InterfaceERC1155            = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	InterfaceERC1155MetadataURI = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+	InterfaceERC2981            = [4]byte{0x2a, 0x55, 0x20, 0x5a}
+
+	// interfaceInvalid 按 EIP-165 必须返回 false 的 interfaceId
+	interfaceInvalid = [4]byte{0xff, 0xff, 0xff, 0xff}
+)
+
+// KnownInterfaces Classify 逐个检测的接口及其名称，按输出顺序排列
+var KnownInterfaces = []struct {
+	Name string
+	ID   [4]byte
+}{
+	{"ERC721", InterfaceERC721},
+	{"ERC721Metadata", InterfaceERC721Metadata},
+	{"ERC721Enumerable", InterfaceERC721Enumerable},
+	{"ERC1155", InterfaceERC1155},
+	{"ERC1155MetadataURI", InterfaceERC1155MetadataURI},
+	{"ERC2981", InterfaceERC2981},
+}
+
+// Standard 合约实现的代币标准
+type Standard int
+
+const (
+	StandardUnknown Standard = iota
+	StandardERC20
+	StandardERC721
+	StandardERC1155
+)
+
+func (s Standard) String() string {
+	switch s {
+	case StandardERC20:
+		return "ERC20"
+	case StandardERC721:
+		return "ERC721"
+	case StandardERC1155:
+		return "ERC1155"
+	}
+	return "unknown"
+}
+
+// Classification 对一个未知合约的检测结果
+type Classification struct {
+	Address    common.Address
+	Standard   Standard
+	ERC165     bool     // 是否按 EIP-165 正确实现了 supportsInterface
+	Interfaces []string // 声明支持的 KnownInterfaces 中的接口名称
+}
+
+// SupportsInterface 以 SupportsInterfaceGas 为 gas 上限调用 supportsInterface(id)
+// 回滚、返回数据不是 32 字节或值不是 0/1 时视为不支持，只有网络等错误才返回 error
+func SupportsInterface(ctx context.Context, caller bind.ContractCaller, address common.Address, id [4]byte) (bool, error) {
+	parsed, err := erc721.Erc721MetaData.GetAbi()
+	if err != nil {
+		return false, err
+	}
+	data, err := parsed.Pack("supportsInterface", id)
+	if err != nil {
+		return false, err
+	}
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &address, Gas: SupportsInterfaceGas, Data: data}, nil)
+	if err != nil {
+		if callFailed(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if len(output) != 32 {
+		return false, nil
+	}
+	for _, b := range output[:31] {
+		if b != 0 {
+			return false, nil
+		}
+	}
+	return output[31] == 1, nil
+}
+
+// SupportsERC165 按 EIP-165 的检测步骤判断合约是否实现了 ERC165：
+// supportsInterface(0x01ffc9a7) 返回 true 且 supportsInterface(0xffffffff) 返回 false
+func SupportsERC165(ctx context.Context, caller bind.ContractCaller, address common.Address) (bool, error) {
+	ok, err := SupportsInterface(ctx, caller, address, InterfaceERC165)
+	if err != nil || !ok {
+		return false, err
+	}
+	ok, err = SupportsInterface(ctx, caller, address, interfaceInvalid)
+	if err != nil {
+		return false, err
+	}
+	return !ok, nil
+}
+
+// Classify 判断 address 上的合约实现了哪种代币标准
+// 实现了 ERC165 的合约按声明的接口判断；否则调用 decimals() 和 balanceOf(0) 识别 ERC20，
+// 因此没有实现 ERC165 的早期 NFT（如 CryptoKitties）会被归为 StandardUnknown；
+// 只有调用回滚或 gas 耗尽才算“不支持”，网络或节点错误（限流、header not found 等）返回 error
+func Classify(ctx context.Context, caller bind.ContractCaller, address common.Address) (*Classification, error) {
+	code, err := caller.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("%w: %s", tokens.ErrNotContract, address.Hex())
+	}
+	c := &Classification{Address: address}
+	if c.ERC165, err = SupportsERC165(ctx, caller, address); err != nil {
+		return nil, fmt.Errorf("supportsInterface: %w", err)
+	}
+	if c.ERC165 {
+		for _, known := range KnownInterfaces {
+			ok, err := SupportsInterface(ctx, caller, address, known.ID)
+			if err != nil {
+				return nil, fmt.Errorf("supportsInterface(%s): %w", known.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			c.Interfaces = append(c.Interfaces, known.Name)
+			switch known.ID {
+			case InterfaceERC721:
+				c.Standard = StandardERC721
+			case InterfaceERC1155:
+				c.Standard = StandardERC1155
+			}
+		}
+		if c.Standard != StandardUnknown {
+			return c, nil
+		}
+	}
+	if c.Standard, err = detectERC20(ctx, caller, address); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// detectERC20 decimals() 返回有效精度且 balanceOf(0) 返回 32 字节时认为是 ERC20
+func detectERC20(ctx context.Context, caller bind.ContractCaller, address common.Address) (Standard, error) {
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: crypto.Keccak256([]byte("decimals()"))[:4]}, nil)
+	if err != nil {
+		if callFailed(err) {
+			return StandardUnknown, nil
+		}
+		return StandardUnknown, err
+	}
+	if _, err := tokens.DecodeDecimals(output); err != nil {
+		return StandardUnknown, nil
+	}
+	data := append(crypto.Keccak256([]byte("balanceOf(address)"))[:4], make([]byte, 32)...)
+	output, err = caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		if callFailed(err) {
+			return StandardUnknown, nil
+		}
+		return StandardUnknown, err
+	}
+	if len(output) != 32 {
+		return StandardUnknown, nil
+	}
+	return StandardERC20, nil
+}
+
+// callFailed 调用本身失败（回滚或 gas 耗尽），而不是网络或节点错误
+// 回滚的判断交给 tokens.IsRevert；gas 耗尽时 geth 返回错误码 -32000 和 "out of gas"，没有专门的错误码
+func callFailed(err error) bool {
+	return tokens.IsRevert(err) || strings.Contains(err.Error(), "out of gas")
+}
@@ -0,0 +1,146 @@
+package nft
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/erc721"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrNotOwner from 不持有要转移的代币（ERC721 的 ownerOf 不是 from，或 ERC1155 的余额不足）
+	ErrNotOwner = errors.New("sender does not own the token")
+	// ErrNotApproved 交易发送方既不是持有人，也没有被授权转移该代币
+	ErrNotApproved = errors.New("sender is not owner nor approved")
+	// ErrNoTransferEvent 回执中没有与转移参数一致的事件
+	ErrNoTransferEvent = errors.New("no matching transfer event in receipt")
+)
+
+// Collection 对 ERC721 合约的封装
+type Collection struct {
+	Address common.Address
+
+	contract *erc721.Erc721
+	abi      *abi.ABI
+	backend  bind.ContractBackend
+}
+
+// NewCollection 创建 ERC721 集合，不发起请求；不确定合约类型时先用 Classify 检测
+func NewCollection(address common.Address, backend bind.ContractBackend) (*Collection, error) {
+	contract, err := erc721.NewErc721(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := erc721.Erc721MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Collection{Address: address, contract: contract, abi: parsed, backend: backend}, nil
+}
+
+// Contract 底层的 abigen 绑定
+func (c *Collection) Contract() *erc721.Erc721 {
+	return c.contract
+}
+
+// Name 集合名称
+func (c *Collection) Name(ctx context.Context) (string, error) {
+	return c.contract.Name(&bind.CallOpts{Context: ctx})
+}
+
+// Symbol 集合符号
+func (c *Collection) Symbol(ctx context.Context) (string, error) {
+	return c.contract.Symbol(&bind.CallOpts{Context: ctx})
+}
+
+// OwnerOf tokenId 的持有人，代币不存在（未铸造或已销毁）时合约回滚并返回错误
+func (c *Collection) OwnerOf(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	return c.contract.OwnerOf(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+// BalanceOf owner 持有的代币数量
+func (c *Collection) BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error) {
+	return c.contract.BalanceOf(&bind.CallOpts{Context: ctx}, owner)
+}
+
+// TokenURI tokenId 的元数据 URI
+func (c *Collection) TokenURI(ctx context.Context, tokenID *big.Int) (string, error) {
+	return c.contract.TokenURI(&bind.CallOpts{Context: ctx}, tokenID)
+}
+
+// CanTransfer 检查 operator 能否转移 from 的 tokenId：from 必须是持有人，operator 是持有人、单个代币的被授权地址或全部代币的操作员
+func (c *Collection) CanTransfer(ctx context.Context, operator, from common.Address, tokenID *big.Int) error {
+	opts := &bind.CallOpts{Context: ctx}
+	owner, err := c.contract.OwnerOf(opts, tokenID)
+	if err != nil {
+		return fmt.Errorf("ownerOf(%s): %w", tokenID, err)
+	}
+	if owner != from {
+		return fmt.Errorf("%w: token %s is owned by %s", ErrNotOwner, tokenID, owner.Hex())
+	}
+	if operator == owner {
+		return nil
+	}
+	approved, err := c.contract.GetApproved(opts, tokenID)
+	if err != nil {
+		return fmt.Errorf("getApproved(%s): %w", tokenID, err)
+	}
+	if approved == operator {
+		return nil
+	}
+	ok, err := c.contract.IsApprovedForAll(opts, owner, operator)
+	if err != nil {
+		return fmt.Errorf("isApprovedForAll: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s for token %s", ErrNotApproved, operator.Hex(), tokenID)
+	}
+	return nil
+}
+
+// SafeTransfer 检查持有人和授权并模拟执行后，以 opts.From 的身份发送 safeTransferFrom(from, to, tokenId, data)
+// to 为合约时必须实现 onERC721Received，否则模拟执行会回滚
+func (c *Collection) SafeTransfer(opts *bind.TransactOpts, from, to common.Address, tokenID *big.Int, data []byte) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.CanTransfer(ctx, opts.From, from, tokenID); err != nil {
+		return nil, err
+	}
+	input, err := c.abi.Pack("safeTransferFrom0", from, to, tokenID, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.backend.CallContract(ctx, ethereum.CallMsg{From: opts.From, To: &c.Address, Data: input}, nil); err != nil {
+		return nil, fmt.Errorf("simulate safeTransferFrom: %w", err)
+	}
+	return c.contract.SafeTransferFrom0(opts, from, to, tokenID, data)
+}
+
+// VerifyTransfer 在回执中查找本集合 tokenId 从 from 到 to 的 Transfer 事件
+func (c *Collection) VerifyTransfer(receipt *types.Receipt, from, to common.Address, tokenID *big.Int) (*erc721.Erc721Transfer, error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", receipt.TxHash.Hex())
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != c.Address || len(log.Topics) != 4 || log.Topics[0] != c.abi.Events["Transfer"].ID {
+			continue
+		}
+		event, err := c.contract.ParseTransfer(*log)
+		if err != nil {
+			continue
+		}
+		if event.From == from && event.To == to && event.TokenId.Cmp(tokenID) == 0 {
+			return event, nil
+		}
+	}
+	return nil, ErrNoTransferEvent
+}
@@ -0,0 +1,253 @@
+package nft
+
+import (
+	"eth-client-study/study/erc1155"
+	"eth-client-study/study/erc721"
+	"eth-client-study/study/replay"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Transfer 一次 NFT 转移：ERC721 的 Transfer、ERC1155 的 TransferSingle，或 TransferBatch 中的一项
+// From 为零地址表示铸造，To 为零地址表示销毁；ERC721 的 Value 固定为 1，Operator 为零地址
+type Transfer struct {
+	Operator   common.Address `json:"operator"`
+	From       common.Address `json:"from"`
+	To         common.Address `json:"to"`
+	ID         *big.Int       `json:"id"`
+	Value      *big.Int       `json:"value"`
+	Block      uint64         `json:"block"`
+	LogIndex   uint           `json:"logIndex"`
+	BatchIndex uint           `json:"batchIndex"` // 在 TransferBatch 中的下标，其他事件为 0
+	TxHash     common.Hash    `json:"txHash"`
+}
+
+// Holding 一个地址持有的某个 id 及数量
+type Holding struct {
+	Owner   common.Address `json:"owner"`
+	ID      *big.Int       `json:"id"`
+	Balance *big.Int       `json:"balance"`
+}
+
+// Indexer 通过回放 ERC721 Transfer 和 ERC1155 TransferSingle/TransferBatch 事件记录每个 id 的持有人
+// 与 holders.Indexer 一样必须从合约部署区块开始回放；同一合约同时发出两种标准的事件时一并计入
+// Sync、Watch、ApplyLog、Rewind 等由 replay.Replayer 提供，TransferBatch 按整条日志计入和撤销
+type Indexer struct {
+	*replay.Replayer[Transfer]
+
+	address  common.Address
+	erc721   *erc721.Erc721Filterer
+	erc1155  *erc1155.Erc1155Filterer
+	transfer common.Hash // ERC721 Transfer 的 topic0，与 ERC20 Transfer 相同，靠 topic 数量区分
+	single   common.Hash // ERC1155 TransferSingle 的 topic0
+	batch    common.Hash // ERC1155 TransferBatch 的 topic0
+
+	mu       sync.RWMutex
+	balances map[common.Hash]map[common.Address]*big.Int // id -> 持有人 -> 数量，不含零地址和零余额
+}
+
+// NewIndexer 创建索引器
+func NewIndexer(address common.Address, backend bind.ContractFilterer) (*Indexer, error) {
+	filterer721, err := erc721.NewErc721Filterer(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	filterer1155, err := erc1155.NewErc1155Filterer(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	abi721, err := erc721.Erc721MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	abi1155, err := erc1155.Erc1155MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	x := &Indexer{
+		address:  address,
+		erc721:   filterer721,
+		erc1155:  filterer1155,
+		transfer: abi721.Events["Transfer"].ID,
+		single:   abi1155.Events["TransferSingle"].ID,
+		batch:    abi1155.Events["TransferBatch"].ID,
+		balances: make(map[common.Hash]map[common.Address]*big.Int),
+	}
+	query := ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{x.transfer, x.single, x.batch}}}
+	x.Replayer = replay.New(backend, query, replay.Handler[Transfer]{Parse: x.Parse, Apply: x.apply, Revert: x.revert})
+	return x, nil
+}
+
+// Address 合约地址
+func (x *Indexer) Address() common.Address {
+	return x.address
+}
+
+// Parse 把一条日志解析为转移记录，TransferBatch 展开为每个 id 一条；不是 NFT 转移事件的日志（包括 ERC20 Transfer）返回空
+func (x *Indexer) Parse(log types.Log) ([]Transfer, error) {
+	if log.Address != x.address || len(log.Topics) == 0 {
+		return nil, nil
+	}
+	base := Transfer{Block: log.BlockNumber, LogIndex: log.Index, TxHash: log.TxHash}
+	switch log.Topics[0] {
+	case x.transfer:
+		// ERC20 的 Transfer 只有 3 个 topic，金额在 data 中
+		if len(log.Topics) != 4 {
+			return nil, nil
+		}
+		event, err := x.erc721.ParseTransfer(log)
+		if err != nil {
+			return nil, fmt.Errorf("parse Transfer: %w", err)
+		}
+		base.From, base.To, base.ID, base.Value = event.From, event.To, event.TokenId, big.NewInt(1)
+		return []Transfer{base}, nil
+	case x.single:
+		event, err := x.erc1155.ParseTransferSingle(log)
+		if err != nil {
+			return nil, fmt.Errorf("parse TransferSingle: %w", err)
+		}
+		base.Operator, base.From, base.To, base.ID, base.Value = event.Operator, event.From, event.To, event.Id, event.Value
+		return []Transfer{base}, nil
+	case x.batch:
+		event, err := x.erc1155.ParseTransferBatch(log)
+		if err != nil {
+			return nil, fmt.Errorf("parse TransferBatch: %w", err)
+		}
+		if len(event.Ids) != len(event.Values) {
+			return nil, fmt.Errorf("TransferBatch has %d ids but %d values", len(event.Ids), len(event.Values))
+		}
+		transfers := make([]Transfer, len(event.Ids))
+		for i := range event.Ids {
+			t := base
+			t.Operator, t.From, t.To, t.ID, t.Value, t.BatchIndex = event.Operator, event.From, event.To, event.Ids[i], event.Values[i], uint(i)
+			transfers[i] = t
+		}
+		return transfers, nil
+	}
+	return nil, nil
+}
+
+func (x *Indexer) apply(t Transfer) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.move(t.ID, t.From, t.To, t.Value)
+}
+
+func (x *Indexer) revert(t Transfer) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.move(t.ID, t.To, t.From, t.Value)
+}
+
+// move 把 id 的 value 个从 from 记到 to，零地址一侧（铸造或销毁）不记录
+func (x *Indexer) move(id *big.Int, from, to common.Address, value *big.Int) {
+	key := common.BigToHash(id)
+	if from != (common.Address{}) {
+		x.add(key, from, new(big.Int).Neg(value))
+	}
+	if to != (common.Address{}) {
+		x.add(key, to, value)
+	}
+}
+
+func (x *Indexer) add(key common.Hash, account common.Address, delta *big.Int) {
+	owners, ok := x.balances[key]
+	if !ok {
+		owners = make(map[common.Address]*big.Int)
+		x.balances[key] = owners
+	}
+	balance, ok := owners[account]
+	if !ok {
+		balance = new(big.Int)
+		owners[account] = balance
+	}
+	if balance.Add(balance, delta).Sign() == 0 {
+		delete(owners, account)
+		if len(owners) == 0 {
+			delete(x.balances, key)
+		}
+	}
+}
+
+// OwnerOf ERC721 代币 id 的持有人；id 未铸造、已销毁或有多个持有人（ERC1155）时 ok 为 false
+func (x *Indexer) OwnerOf(id *big.Int) (common.Address, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	owners := x.balances[common.BigToHash(id)]
+	if len(owners) != 1 {
+		return common.Address{}, false
+	}
+	for owner := range owners {
+		return owner, true
+	}
+	return common.Address{}, false
+}
+
+// Balance account 持有的 id 数量
+func (x *Indexer) Balance(account common.Address, id *big.Int) *big.Int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if balance, ok := x.balances[common.BigToHash(id)][account]; ok {
+		return new(big.Int).Set(balance)
+	}
+	return new(big.Int)
+}
+
+// TokensOf account 持有的全部 id，按 id 升序排列
+func (x *Indexer) TokensOf(account common.Address) []Holding {
+	x.mu.RLock()
+	var holdings []Holding
+	for key, owners := range x.balances {
+		if balance, ok := owners[account]; ok {
+			holdings = append(holdings, Holding{Owner: account, ID: key.Big(), Balance: new(big.Int).Set(balance)})
+		}
+	}
+	x.mu.RUnlock()
+	sort.Slice(holdings, func(i, j int) bool { return holdings[i].ID.Cmp(holdings[j].ID) < 0 })
+	return holdings
+}
+
+// Owners id 的全部持有人，按数量降序排列，数量相同时按地址排序
+func (x *Indexer) Owners(id *big.Int) []Holding {
+	x.mu.RLock()
+	owners := x.balances[common.BigToHash(id)]
+	holdings := make([]Holding, 0, len(owners))
+	for account, balance := range owners {
+		holdings = append(holdings, Holding{Owner: account, ID: new(big.Int).Set(id), Balance: new(big.Int).Set(balance)})
+	}
+	x.mu.RUnlock()
+	sort.Slice(holdings, func(i, j int) bool {
+		if c := holdings[i].Balance.Cmp(holdings[j].Balance); c != 0 {
+			return c > 0
+		}
+		return holdings[i].Owner.Cmp(holdings[j].Owner) < 0
+	})
+	return holdings
+}
+
+// Tokens 当前至少有一个持有人的 id 数量
+func (x *Indexer) Tokens() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.balances)
+}
+
+// Holders 至少持有一个 id 的地址数
+func (x *Indexer) Holders() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	accounts := make(map[common.Address]struct{})
+	for _, owners := range x.balances {
+		for account := range owners {
+			accounts[account] = struct{}{}
+		}
+	}
+	return len(accounts)
+}
@@ -0,0 +1,156 @@
+// nft_inspect.go - 用 ERC-165 判断合约类型，读取 NFT 的所有权和元数据，并可回放转移事件统计持有人
+// 用法：
+//
+//	go run study/nft_inspect.go 0x...
+//	go run study/nft_inspect.go -id 1 -owner 0x... 0x...
+//	go run study/nft_inspect.go -index -owner alice 0x...
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/blocktime"
+	"eth-client-study/study/nft"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	idFlag := flag.String("id", "", "查询该 tokenId 的持有人和元数据 URI（十进制或 0x 开头的十六进制）")
+	ownerFlag := flag.String("owner", "", "查询该地址或地址簿名称持有的数量；配合 -index 时列出其持有的全部 id")
+	indexFlag := flag.Bool("index", false, "从部署区块开始回放转移事件，统计持有人")
+	fromFlag := flag.Uint64("from", 0, "回放起始区块，0 表示二分查找合约部署区块")
+	blockFlag := flag.String("block", "", "与 -index 一起使用，回放截止的区块：latest、safe、finalized 或区块号，为空时使用 BLOCK_TAG（默认 latest）")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("用法：go run study/nft_inspect.go [-id N] [-owner 地址或名称] [-index] <合约地址或名称>")
+	}
+	var id *big.Int
+	if *idFlag != "" {
+		var ok bool
+		if id, ok = new(big.Int).SetString(*idFlag, 0); !ok || id.Sign() < 0 {
+			log.Fatalf("无效的 tokenId：%s", *idFlag)
+		}
+	}
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	// 合约和 -owner 可以是地址，也可以是地址簿中的名称
+	book := addressbook.MustOpen(ctx, client)
+	address := book.MustResolve("合约地址", flag.Arg(0))
+	var owner common.Address
+	if *ownerFlag != "" {
+		owner = book.MustResolve("-owner", *ownerFlag)
+	}
+
+	c, err := nft.Classify(ctx, client, address)
+	if err != nil {
+		log.Fatal("检测合约类型失败：", err)
+	}
+	fmt.Printf("%s：%s\n", book.Annotate(address), c.Standard)
+	if c.ERC165 {
+		fmt.Println("支持的接口：", strings.Join(c.Interfaces, ", "))
+	} else {
+		fmt.Println("未实现 ERC165")
+	}
+
+	switch c.Standard {
+	case nft.StandardERC721:
+		collection, err := nft.NewCollection(address, client)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name, err := collection.Name(ctx); err == nil {
+			symbol, _ := collection.Symbol(ctx)
+			fmt.Printf("名称：%s，符号：%s\n", name, symbol)
+		}
+		if id != nil {
+			holder, err := collection.OwnerOf(ctx, id)
+			if err != nil {
+				fmt.Printf("#%s 不存在：%v\n", id, err)
+			} else {
+				fmt.Printf("#%s 持有人：%s\n", id, holder.Hex())
+				if uri, err := collection.TokenURI(ctx, id); err == nil {
+					fmt.Printf("#%s 元数据：%s\n", id, uri)
+				}
+			}
+		}
+		if owner != (common.Address{}) {
+			balance, err := collection.BalanceOf(ctx, owner)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s 持有 %s 个\n", owner.Hex(), balance)
+		}
+	case nft.StandardERC1155:
+		multi, err := nft.NewMultiToken(address, client)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if id != nil {
+			if uri, err := multi.URI(ctx, id); err == nil {
+				fmt.Printf("#%s 元数据：%s\n", id, uri)
+			}
+			if owner != (common.Address{}) {
+				balance, err := multi.BalanceOf(ctx, owner, id)
+				if err != nil {
+					log.Fatal(err)
+				}
+				fmt.Printf("%s 持有 #%s %s 个\n", owner.Hex(), id, balance)
+			}
+		}
+	}
+
+	if !*indexFlag {
+		return
+	}
+	if c.Standard != nft.StandardERC721 && c.Standard != nft.StandardERC1155 {
+		log.Fatalf("%s 不是 NFT 合约", c.Standard)
+	}
+	sel, err := blockref.ParseOrDefault(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	header, err := blockref.Header(ctx, client, sel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	head := header.Number.Uint64()
+	from := *fromFlag
+	if from == 0 {
+		if from, err = blocktime.DeploymentBlock(ctx, client, address, head); err != nil {
+			log.Fatal("查找部署区块失败：", err)
+		}
+		fmt.Println("合约部署区块：", from)
+	}
+	indexer, err := nft.NewIndexer(address, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := indexer.Sync(ctx, from, head); err != nil {
+		log.Fatal("回放事件失败：", err)
+	}
+	fmt.Printf("已同步到区块 %d，存在的 id %d 个，持有人 %d 个\n", indexer.Synced(), indexer.Tokens(), indexer.Holders())
+	if id != nil {
+		for _, h := range indexer.Owners(id) {
+			fmt.Printf("#%s：%s 持有 %s 个\n", id, h.Owner.Hex(), h.Balance)
+		}
+	}
+	if owner != (common.Address{}) {
+		for _, h := range indexer.TokensOf(owner) {
+			fmt.Printf("%s 持有 #%s %s 个\n", owner.Hex(), h.ID, h.Balance)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// nft_transfer.go - 用 safeTransferFrom 转移 ERC721，或用 safeBatchTransferFrom 批量转移 ERC1155
+// 发送前用 ERC-165 判断合约类型，检查所有权、授权和余额并模拟执行
+// 用法：
+//
+//	go run study/nft_transfer.go -token 0x... -to bob -id 1
+//	go run study/nft_transfer.go -token 0x... -to 0x... -id 1,2,3 -amount 10,1,5
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/nft"
+	"eth-client-study/utils"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// parseList 解析逗号分隔的非负整数
+func parseList(s string) ([]*big.Int, error) {
+	var values []*big.Int
+	for _, part := range strings.Split(s, ",") {
+		value, ok := new(big.Int).SetString(strings.TrimSpace(part), 0)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("无效的数字：%q", part)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func main() {
+	tokenFlag := flag.String("token", "", "NFT 合约地址或地址簿名称")
+	toFlag := flag.String("to", "carol", "接收地址或地址簿名称")
+	idFlag := flag.String("id", "", "tokenId，ERC1155 可用逗号分隔多个")
+	amountFlag := flag.String("amount", "1", "ERC1155 每个 id 的转移数量，逗号分隔，与 -id 一一对应")
+	flag.Parse()
+	if *tokenFlag == "" || *idFlag == "" {
+		log.Fatal("用法：go run study/nft_transfer.go -token <合约地址或名称> -to <接收地址或名称> -id <tokenId>")
+	}
+	ids, err := parseList(*idFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	book := addressbook.MustOpen(ctx, client)
+	address, to := book.MustResolve("-token", *tokenFlag), book.MustResolve("-to", *toFlag)
+
+	//账户私钥
+	privateKey, err := crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1"))
+	if err != nil {
+		log.Fatal("privateKey err:", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts.Context = ctx
+
+	c, err := nft.Classify(ctx, client, address)
+	if err != nil {
+		log.Fatal("检测合约类型失败：", err)
+	}
+	var tx *types.Transaction
+	switch c.Standard {
+	case nft.StandardERC721:
+		if len(ids) != 1 {
+			log.Fatal("ERC721 一次只能转移一个 tokenId")
+		}
+		collection, err := nft.NewCollection(address, client)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("转移 ERC721 #%s：%s -> %s\n", ids[0], opts.From.Hex(), to.Hex())
+		if tx, err = collection.SafeTransfer(opts, opts.From, to, ids[0], nil); err != nil {
+			log.Fatal("转移失败：", err)
+		}
+		fmt.Printf("交易发送成功！TxHash：%s\n", tx.Hash().Hex())
+		receipt, err := bind.WaitMined(ctx, client, tx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := collection.VerifyTransfer(receipt, opts.From, to, ids[0]); err != nil {
+			log.Fatal("校验 Transfer 事件失败：", err)
+		}
+		fmt.Printf("已上链：区块 %d\n", receipt.BlockNumber)
+	case nft.StandardERC1155:
+		amounts, err := parseList(*amountFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(amounts) != len(ids) {
+			log.Fatalf("-id 有 %d 项，-amount 有 %d 项", len(ids), len(amounts))
+		}
+		multi, err := nft.NewMultiToken(address, client)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i := range ids {
+			fmt.Printf("转移 ERC1155 #%s × %s：%s -> %s\n", ids[i], amounts[i], opts.From.Hex(), to.Hex())
+		}
+		if tx, err = multi.SafeBatchTransfer(opts, opts.From, to, ids, amounts, nil); err != nil {
+			log.Fatal("转移失败：", err)
+		}
+		fmt.Printf("交易发送成功！TxHash：%s\n", tx.Hash().Hex())
+		receipt, err := bind.WaitMined(ctx, client, tx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			log.Fatal("交易执行失败")
+		}
+		fmt.Printf("已上链：区块 %d\n", receipt.BlockNumber)
+	default:
+		log.Fatalf("%s 不是 NFT 合约（%s）", address.Hex(), c.Standard)
+	}
+}
@@ -0,0 +1,115 @@
+package payout
+
+import (
+	"encoding/csv"
+	"errors"
+	"eth-client-study/utils"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Row 付款 CSV 中的一行：recipient,amount[,token]
+type Row struct {
+	Line      int // 在 CSV 文件中的行号，从 1 开始，作为该笔付款在状态文件中的标识
+	Recipient common.Address
+	Amount    string          // 十进制金额，按 ETH 或代币的精度解析，如 "1.5"
+	Token     *common.Address // ERC20 代币地址，nil 表示 ETH
+
+	NoChecksum bool // 收款人或代币地址全小写或全大写，没有校验和，发送前应提示核对
+}
+
+// Asset 付款的资产，ETH 为零地址
+func (r Row) Asset() common.Address {
+	if r.Token == nil {
+		return common.Address{}
+	}
+	return *r.Token
+}
+
+// ReadCSV 解析付款 CSV，第一行以 recipient 开头时视为表头，# 开头的行为注释
+// 所有行都会被检查：地址格式和校验和（见 utils.ParseAddress）、金额不为空、同一收款人同一资产不重复，错误合并后一并返回
+func ReadCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+
+	var (
+		rows []Row
+		errs []error
+	)
+	type key struct{ recipient, asset common.Address }
+	seen := make(map[key]int)
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "recipient") {
+			continue
+		}
+		row, err := parseRow(line, record)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		k := key{row.Recipient, row.Asset()}
+		if prev, ok := seen[k]; ok {
+			errs = append(errs, fmt.Errorf("line %d: duplicate of line %d (%s)", line, prev, row.Recipient.Hex()))
+			continue
+		}
+		seen[k] = line
+		rows = append(rows, row)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// LoadCSV 从本地文件读取付款 CSV
+func LoadCSV(path string) ([]Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadCSV(f)
+}
+
+func parseRow(line int, record []string) (Row, error) {
+	for i := range record {
+		record[i] = strings.TrimSpace(record[i])
+	}
+	if len(record) < 2 || len(record) > 3 {
+		return Row{}, fmt.Errorf("want recipient,amount[,token], got %d fields", len(record))
+	}
+	recipient, err := utils.ParseAddress(record[0])
+	if err != nil {
+		return Row{}, fmt.Errorf("recipient: %w", err)
+	}
+	if recipient == (common.Address{}) {
+		return Row{}, errors.New("recipient is the zero address")
+	}
+	if record[1] == "" {
+		return Row{}, errors.New("empty amount")
+	}
+	row := Row{Line: line, Recipient: recipient, Amount: record[1], NoChecksum: !utils.HasChecksum(record[0])}
+	if len(record) == 3 && record[2] != "" {
+		token, err := utils.ParseAddress(record[2])
+		if err != nil {
+			return Row{}, fmt.Errorf("token: %w", err)
+		}
+		row.Token = &token
+		row.NoChecksum = row.NoChecksum || !utils.HasChecksum(record[2])
+	}
+	return row, nil
+}
@@ -0,0 +1,560 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	token "eth-client-study/study/erc20"
+	"eth-client-study/study/tokens"
+	"eth-client-study/utils"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrInsufficientFunds 发送方的 ETH 或代币余额不足以完成全部待付款项
+var ErrInsufficientFunds = errors.New("insufficient funds for payouts")
+
+// ReplacementBump Replace 重新签名时小费和 maxFeePerGas 至少提高的百分比，geth 的交易池要求不低于 10%
+const ReplacementBump = 25
+
+// Backend 批量付款需要的节点接口，*ethclient.Client 满足该接口
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Payer 按状态文件批量发送 ETH 和 ERC20 付款
+// 流程为 Prepare（解析金额）-> Check（估算 gas、检查余额）-> Send（签名并连续发送）-> Wait（等待确认）
+// 每一步都可以在崩溃后重新运行，已签名的付款不会用新的 nonce 再签一次
+// Wait 超过 MaxWait 仍未上链的付款标记为 stuck，之后可调用 Replace 用同一 nonce 和更高的手续费重新发送
+type Payer struct {
+	backend Backend
+	opts    *bind.TransactOpts
+	state   *State
+	tokens  map[common.Address]*tokens.Token
+	erc20   *abi.ABI
+
+	gasTipCap *big.Int
+	gasFeeCap *big.Int
+
+	Confirmations uint64        // Wait 要求的确认数，1 表示上链即可
+	PollInterval  time.Duration // Wait 轮询回执的间隔
+	MaxWait       time.Duration // 交易广播后超过该时间仍未上链时 Wait 将其标记为 stuck，0 表示一直等待
+}
+
+// NewPayer 创建付款器，opts.From 必须是状态文件中的发送方
+func NewPayer(backend Backend, opts *bind.TransactOpts, state *State) (*Payer, error) {
+	if opts.From != state.Sender {
+		return nil, fmt.Errorf("state sender is %s, transactor is %s", state.Sender.Hex(), opts.From.Hex())
+	}
+	parsed, err := token.Erc20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Payer{
+		backend:       backend,
+		opts:          opts,
+		state:         state,
+		tokens:        make(map[common.Address]*tokens.Token),
+		erc20:         parsed,
+		Confirmations: 1,
+		PollInterval:  2 * time.Second,
+	}, nil
+}
+
+// Token 返回 address 对应的代币，元数据只读取一次
+func (p *Payer) Token(ctx context.Context, address common.Address) (*tokens.Token, error) {
+	if t, ok := p.tokens[address]; ok {
+		return t, nil
+	}
+	t, err := tokens.NewToken(ctx, address, p.backend)
+	if err != nil {
+		return nil, err
+	}
+	if t.Quirks.Has(tokens.QuirkNoDecimals) || t.Quirks.Has(tokens.QuirkBadDecimals) {
+		return nil, fmt.Errorf("token %s has no valid decimals() (%s)", address.Hex(), t.Quirks)
+	}
+	p.tokens[address] = t
+	return t, nil
+}
+
+// Format 按资产精度格式化金额并附上符号
+func (p *Payer) Format(asset common.Address, value *big.Int) string {
+	if asset == (common.Address{}) {
+		return utils.FormatUnits(value, 18) + " ETH"
+	}
+	if t, ok := p.tokens[asset]; ok {
+		return t.Format(value)
+	}
+	return value.String()
+}
+
+// Prepare 把 CSV 行与状态文件对齐：新行按资产精度换算金额后记为 pending，已有的行必须与记录一致
+// Prepare 和 Check 只修改内存中的状态，由 Send 写回文件
+// 状态文件中未完成但 CSV 中已不存在的行同样视为 ErrRowChanged
+func (p *Payer) Prepare(ctx context.Context, rows []Row) error {
+	lines := make(map[int]bool, len(rows))
+	var errs []error
+	for _, row := range rows {
+		lines[row.Line] = true
+		entry, err := p.state.lookup(row)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if entry != nil {
+			if entry.Token != nil {
+				if _, err := p.Token(ctx, *entry.Token); err != nil {
+					errs = append(errs, fmt.Errorf("line %d: %w", row.Line, err))
+				}
+			}
+			continue
+		}
+		decimals := uint8(18)
+		if row.Token != nil {
+			t, err := p.Token(ctx, *row.Token)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", row.Line, err))
+				continue
+			}
+			decimals = t.Decimals
+		}
+		value, err := utils.ParseUnits(row.Amount, decimals)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", row.Line, err))
+			continue
+		}
+		if value.Sign() <= 0 {
+			errs = append(errs, fmt.Errorf("line %d: amount %q must be positive", row.Line, row.Amount))
+			continue
+		}
+		p.state.Entries = append(p.state.Entries, &Entry{
+			Line:      row.Line,
+			Recipient: row.Recipient,
+			Token:     row.Token,
+			Amount:    row.Amount,
+			Value:     value,
+			Status:    StatusPending,
+		})
+	}
+	for _, e := range p.state.Entries {
+		if !lines[e.Line] && e.Status != StatusConfirmed && e.Status != StatusFailed {
+			errs = append(errs, fmt.Errorf("%w: line %d (%s) is %s but no longer in CSV", ErrRowChanged, e.Line, e.Recipient.Hex(), e.Status))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	sort.Slice(p.state.Entries, func(i, j int) bool { return p.state.Entries[i].Line < p.state.Entries[j].Line })
+	return nil
+}
+
+// fees 读取一次 EIP-1559 费用参数，整批交易使用相同的 maxFeePerGas = 2 * baseFee + tip
+func (p *Payer) fees(ctx context.Context) error {
+	if p.gasFeeCap != nil {
+		return nil
+	}
+	head, err := p.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if head.BaseFee == nil {
+		return errors.New("chain does not support EIP-1559")
+	}
+	tip, err := p.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return err
+	}
+	p.gasTipCap = tip
+	p.gasFeeCap = new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip)
+	return nil
+}
+
+func (p *Payer) callMsg(e *Entry) (ethereum.CallMsg, error) {
+	if e.Token == nil {
+		return ethereum.CallMsg{From: p.opts.From, To: &e.Recipient, Value: e.Value}, nil
+	}
+	data, err := p.erc20.Pack("transfer", e.Recipient, e.Value)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+	return ethereum.CallMsg{From: p.opts.From, To: e.Token, Data: data}, nil
+}
+
+// Check 检查发送方余额能否覆盖全部待付金额，再估算每笔的 gas：
+// 每种代币的余额不少于其合计金额，ETH 余额不少于 ETH 付款合计加上按 maxFeePerGas 计算的最高手续费
+func (p *Payer) Check(ctx context.Context) error {
+	if err := p.fees(ctx); err != nil {
+		return err
+	}
+	totals := map[common.Address]*big.Int{{}: new(big.Int)}
+	for _, e := range p.state.Entries {
+		if e.Status != StatusPending {
+			continue
+		}
+		total, ok := totals[e.Asset()]
+		if !ok {
+			total = new(big.Int)
+			totals[e.Asset()] = total
+		}
+		total.Add(total, e.Value)
+	}
+	var errs []error
+	for asset, total := range totals {
+		if asset == (common.Address{}) {
+			continue
+		}
+		balance, err := p.tokens[asset].BalanceOf(ctx, p.opts.From)
+		if err != nil {
+			return err
+		}
+		if balance.Cmp(total) < 0 {
+			errs = append(errs, fmt.Errorf("%w: have %s, need %s", ErrInsufficientFunds, p.Format(asset, balance), p.Format(asset, total)))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	need := totals[common.Address{}]
+	for _, e := range p.state.Entries {
+		if e.Status != StatusPending {
+			continue
+		}
+		msg, err := p.callMsg(e)
+		if err != nil {
+			return err
+		}
+		if e.Gas, err = p.backend.EstimateGas(ctx, msg); err != nil {
+			return fmt.Errorf("line %d: estimate gas: %w", e.Line, err)
+		}
+		need.Add(need, new(big.Int).Mul(new(big.Int).SetUint64(e.Gas), p.gasFeeCap))
+	}
+	balance, err := p.backend.BalanceAt(ctx, p.opts.From, nil)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(need) < 0 {
+		return fmt.Errorf("%w: have %s, need %s including gas", ErrInsufficientFunds, p.Format(common.Address{}, balance), p.Format(common.Address{}, need))
+	}
+	return nil
+}
+
+// Send 先处理上次运行遗留的已签名交易，再按行号顺序为每笔 pending 付款分配连续的 nonce、签名、落盘后广播
+// 广播失败时停止，已签名但不确定是否广播的付款保持 signed，下次运行会重发同一笔交易
+func (p *Payer) Send(ctx context.Context) error {
+	if err := p.fees(ctx); err != nil {
+		return err
+	}
+	nonce, err := p.recover(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range p.state.Entries {
+		if e.Status != StatusPending {
+			continue
+		}
+		tx, err := p.sign(e, nonce, p.gasTipCap, p.gasFeeCap)
+		if err != nil {
+			return fmt.Errorf("line %d: sign: %w", e.Line, err)
+		}
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		e.Status, e.Nonce, e.TxHash, e.RawTx, e.Error = StatusSigned, nonce, tx.Hash(), raw, ""
+		if err := p.state.Save(); err != nil {
+			return err
+		}
+		if err := p.backend.SendTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("line %d: send %s: %w", e.Line, tx.Hash().Hex(), err)
+		}
+		e.Status, e.SentAt = StatusSent, time.Now().Unix()
+		if err := p.state.Save(); err != nil {
+			return err
+		}
+		nonce++
+	}
+	return nil
+}
+
+// recover 核对 signed、sent 和 stuck 的付款：已上链的留给 Wait 处理；nonce 仍未使用的重新广播最近签名的交易，
+// 节点拒绝（已在交易池中除外）的标记为 stuck 并记录错误，由 Replace 提高手续费后重新签名；
+// nonce 已被其他交易占用的标记为 failed（原交易及其替换交易再也不会上链，不会重复付款，但需要人工确认）
+// 返回下一笔新交易应使用的 nonce
+func (p *Payer) recover(ctx context.Context) (uint64, error) {
+	confirmed, err := p.backend.NonceAt(ctx, p.opts.From, nil)
+	if err != nil {
+		return 0, err
+	}
+	pending, err := p.backend.PendingNonceAt(ctx, p.opts.From)
+	if err != nil {
+		return 0, err
+	}
+	next := max(confirmed, pending)
+	for _, e := range p.state.Entries {
+		if e.Status != StatusSigned && e.Status != StatusSent && e.Status != StatusStuck {
+			continue
+		}
+		next = max(next, e.Nonce+1)
+		receipt, err := p.receipt(ctx, e)
+		if err == nil {
+			e.Status, e.Block = StatusSent, receipt.BlockNumber.Uint64()
+			continue
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return 0, err
+		}
+		if e.Nonce < confirmed {
+			e.Status, e.Error = StatusFailed, fmt.Sprintf("nonce %d was used by another transaction", e.Nonce)
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(e.RawTx); err != nil {
+			return 0, fmt.Errorf("line %d: decode saved transaction: %w", e.Line, err)
+		}
+		if err := p.backend.SendTransaction(ctx, tx); err != nil && !isKnown(err) {
+			// 手续费低于交易池的下限等错误重试也不会成功，标记为 stuck 交给 Replace，不影响其他付款
+			e.Status, e.Error = StatusStuck, fmt.Sprintf("resend %s: %v", tx.Hash().Hex(), err)
+			continue
+		}
+		// stuck 的付款保持 stuck，等待 Replace 或上链
+		if e.Status == StatusSigned {
+			e.Status, e.SentAt = StatusSent, time.Now().Unix()
+		}
+	}
+	return next, p.state.Save()
+}
+
+// receipt 查找付款的回执：当前交易或任一被替换掉的交易，它们的 nonce 相同，最多只有一笔会上链
+// 上链的是被替换掉的交易时，把它换回 TxHash，未上链的替换交易记入 Replaced
+func (p *Payer) receipt(ctx context.Context, e *Entry) (*types.Receipt, error) {
+	receipt, err := p.backend.TransactionReceipt(ctx, e.TxHash)
+	if err == nil || !notFound(err) {
+		return receipt, err
+	}
+	for i, hash := range e.Replaced {
+		receipt, err := p.backend.TransactionReceipt(ctx, hash)
+		if notFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		e.Replaced[i], e.TxHash = e.TxHash, hash
+		return receipt, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+// notFound 节点上还没有该交易的回执；节点正在建立交易索引时同样查不到，按未上链处理
+func notFound(err error) bool {
+	return errors.Is(err, ethereum.NotFound) || err != nil && strings.Contains(err.Error(), "transaction indexing is in progress")
+}
+
+// isKnown 节点的交易池中已有该交易
+func isKnown(err error) bool {
+	return strings.Contains(err.Error(), "already known") || strings.Contains(err.Error(), "known transaction")
+}
+
+func (p *Payer) sign(e *Entry, nonce uint64, tip, feeCap *big.Int) (*types.Transaction, error) {
+	opts := *p.opts
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	opts.GasTipCap, opts.GasFeeCap, opts.GasPrice = tip, feeCap, nil
+	opts.GasLimit = e.Gas
+	opts.NoSend = true
+	if e.Token != nil {
+		return p.tokens[*e.Token].Contract().Transfer(&opts, e.Recipient, e.Value)
+	}
+	if opts.GasLimit == 0 {
+		opts.GasLimit = 21000
+	}
+	chainID := p.state.ChainID
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: opts.GasTipCap,
+		GasFeeCap: opts.GasFeeCap,
+		Gas:       opts.GasLimit,
+		To:        &e.Recipient,
+		Value:     e.Value,
+	})
+	return opts.Signer(opts.From, tx)
+}
+
+// Wait 等待所有 sent 的付款上链并达到 Confirmations 个确认
+// 回滚的交易、或 ERC20 回执中找不到对应 Transfer 事件的付款标记为 failed
+// 设置了 MaxWait 时，广播后超时仍未上链的付款标记为 stuck 并不再等待；stuck 的付款之后上链时照常确认
+func (p *Payer) Wait(ctx context.Context) error {
+	for {
+		waiting := 0
+		head, err := p.backend.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		changed := false
+		for _, e := range p.state.Entries {
+			if e.Status != StatusSent && e.Status != StatusStuck {
+				continue
+			}
+			receipt, err := p.receipt(ctx, e)
+			if errors.Is(err, ethereum.NotFound) {
+				if e.Status == StatusSent && p.stuck(e) {
+					e.Status, e.Error, changed = StatusStuck, fmt.Sprintf("not mined %s after broadcast", p.MaxWait), true
+				}
+				if e.Status == StatusSent {
+					waiting++
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if e.Status == StatusStuck {
+				e.Status, e.Error, changed = StatusSent, "", true
+			}
+			block := receipt.BlockNumber.Uint64()
+			if block != e.Block {
+				// 首次看到回执，或重组后交易被打包进了另一个区块
+				e.Block, changed = block, true
+			}
+			if head+1 < block+p.Confirmations {
+				waiting++
+				continue
+			}
+			e.Status, changed = StatusConfirmed, true
+			if err := p.verify(e, receipt); err != nil {
+				e.Status, e.Error = StatusFailed, err.Error()
+			}
+		}
+		if changed {
+			if err := p.state.Save(); err != nil {
+				return err
+			}
+		}
+		if waiting == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.PollInterval):
+		}
+	}
+}
+
+// stuck 付款广播后是否已超过 MaxWait；旧状态文件中没有广播时间的从现在开始计时
+func (p *Payer) stuck(e *Entry) bool {
+	if p.MaxWait <= 0 {
+		return false
+	}
+	if e.SentAt == 0 {
+		e.SentAt = time.Now().Unix()
+		return false
+	}
+	return time.Since(time.Unix(e.SentAt, 0)) > p.MaxWait
+}
+
+// Replace 对所有 stuck 的付款做 replace-by-fee：用同一个 nonce、同样的收款人和金额、更高的手续费重新签名，
+// 新交易先写入状态文件（TxHash、RawTx，旧哈希记入 Replaced）再广播，返回替换的笔数
+// 小费和 maxFeePerGas 在原交易的基础上至少提高 ReplacementBump%，且不低于当前建议值
+// 同一个 nonce 只有一笔交易能上链，替换不会重复付款；此后 Wait 和 recover 会同时查找新旧交易的回执
+// 已有新旧交易之一上链的付款改回 sent 交给 Wait 处理，nonce 已被其他交易占用的标记为 failed
+func (p *Payer) Replace(ctx context.Context) (int, error) {
+	confirmed, err := p.backend.NonceAt(ctx, p.opts.From, nil)
+	if err != nil {
+		return 0, err
+	}
+	head, err := p.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if head.BaseFee == nil {
+		return 0, errors.New("chain does not support EIP-1559")
+	}
+	suggested, err := p.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return 0, err
+	}
+	replaced := 0
+	for _, e := range p.state.Entries {
+		if e.Status != StatusStuck {
+			continue
+		}
+		if _, err := p.receipt(ctx, e); err == nil {
+			e.Status, e.Error = StatusSent, ""
+			continue
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return replaced, err
+		}
+		if e.Nonce < confirmed {
+			e.Status, e.Error = StatusFailed, fmt.Sprintf("nonce %d was used by another transaction", e.Nonce)
+			continue
+		}
+		old := new(types.Transaction)
+		if err := old.UnmarshalBinary(e.RawTx); err != nil {
+			return replaced, fmt.Errorf("line %d: decode saved transaction: %w", e.Line, err)
+		}
+		tip := bigMax(bump(old.GasTipCap()), suggested)
+		feeCap := bigMax(bump(old.GasFeeCap()), new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip))
+		e.Gas = old.Gas()
+		tx, err := p.sign(e, e.Nonce, tip, feeCap)
+		if err != nil {
+			return replaced, fmt.Errorf("line %d: sign replacement: %w", e.Line, err)
+		}
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return replaced, err
+		}
+		e.Replaced = append(e.Replaced, e.TxHash)
+		e.Status, e.TxHash, e.RawTx, e.Error = StatusSigned, tx.Hash(), raw, ""
+		if err := p.state.Save(); err != nil {
+			return replaced, err
+		}
+		if err := p.backend.SendTransaction(ctx, tx); err != nil && !isKnown(err) {
+			return replaced, fmt.Errorf("line %d: send replacement %s: %w", e.Line, tx.Hash().Hex(), err)
+		}
+		e.Status, e.SentAt = StatusSent, time.Now().Unix()
+		if err := p.state.Save(); err != nil {
+			return replaced, err
+		}
+		replaced++
+	}
+	return replaced, p.state.Save()
+}
+
+// bump 按 ReplacementBump 提高费用，向上取整
+func bump(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+ReplacementBump))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func (p *Payer) verify(e *Entry, receipt *types.Receipt) error {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s reverted", receipt.TxHash.Hex())
+	}
+	if e.Token == nil {
+		return nil
+	}
+	_, err := p.tokens[*e.Token].VerifyTransfer(receipt, p.opts.From, e.Recipient, e.Value)
+	return err
+}
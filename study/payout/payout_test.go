@@ -0,0 +1,298 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/simulated"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// flakyBackend 可以让广播丢失或被拒绝的节点
+type flakyBackend struct {
+	Backend
+	drop   bool  // 返回成功但不广播，交易不会进入交易池
+	reject error // 拒绝广播
+}
+
+func (b *flakyBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if b.reject != nil {
+		return b.reject
+	}
+	if b.drop {
+		return nil
+	}
+	return b.Backend.SendTransaction(ctx, tx)
+}
+
+func newHarness(t *testing.T) *simulated.Harness {
+	t.Helper()
+	h, err := simulated.NewHarness(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { h.Close() })
+	return h
+}
+
+// openPayer 以账户 0 为发送方打开 path 处的状态文件，模拟一次新的运行
+func openPayer(t *testing.T, h *simulated.Harness, backend Backend, path string) *Payer {
+	t.Helper()
+	opts, err := h.TransactOpts(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := OpenState(path, opts.From, h.ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPayer(backend, opts, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.PollInterval = 10 * time.Millisecond
+	return p
+}
+
+// prepare 按 batch_payout.go 的顺序执行 Prepare 和 Check
+func prepare(t *testing.T, p *Payer, rows []Row) {
+	t.Helper()
+	ctx := context.Background()
+	if err := p.Prepare(ctx, rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func wait(t *testing.T, p *Payer) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := p.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.Ether))
+}
+
+func balance(t *testing.T, h *simulated.Harness, account common.Address) *big.Int {
+	t.Helper()
+	b, err := h.Client.BalanceAt(context.Background(), account, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func nonce(t *testing.T, h *simulated.Harness) uint64 {
+	t.Helper()
+	n, err := h.Client.NonceAt(context.Background(), h.Accounts[0], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// 第一笔交易签名落盘后、广播前崩溃：重新运行时重发同一笔交易，不会用新的 nonce 再付一次
+func TestSendAfterCrashBeforeBroadcast(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.json")
+	rows := []Row{
+		{Line: 2, Recipient: h.Accounts[1], Amount: "1"},
+		{Line: 3, Recipient: h.Accounts[2], Amount: "2"},
+	}
+	before1, before2 := balance(t, h, h.Accounts[1]), balance(t, h, h.Accounts[2])
+
+	p := openPayer(t, h, h.Client, path)
+	prepare(t, p, rows)
+	if err := p.fees(ctx); err != nil {
+		t.Fatal(err)
+	}
+	e := p.state.Entries[0]
+	tx, err := p.sign(e, 0, p.gasTipCap, p.gasFeeCap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Status, e.Nonce, e.TxHash, e.RawTx = StatusSigned, 0, tx.Hash(), raw
+	if err := p.state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	p = openPayer(t, h, h.Client, path)
+	prepare(t, p, rows)
+	if err := p.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	h.Commit()
+	wait(t, p)
+
+	for _, e := range p.state.Entries {
+		if e.Status != StatusConfirmed {
+			t.Errorf("line %d: status %s (%s), want confirmed", e.Line, e.Status, e.Error)
+		}
+	}
+	if got := p.state.Entries[0].TxHash; got != tx.Hash() {
+		t.Errorf("line 2 was re-signed: tx %s, want %s", got.Hex(), tx.Hash().Hex())
+	}
+	if n := nonce(t, h); n != 2 {
+		t.Errorf("sender nonce %d, want 2", n)
+	}
+	if got, want := balance(t, h, h.Accounts[1]), new(big.Int).Add(before1, ether(1)); got.Cmp(want) != 0 {
+		t.Errorf("recipient 1 balance %s, want %s", got, want)
+	}
+	if got, want := balance(t, h, h.Accounts[2]), new(big.Int).Add(before2, ether(2)); got.Cmp(want) != 0 {
+		t.Errorf("recipient 2 balance %s, want %s", got, want)
+	}
+}
+
+// Replace 之后上链的是原交易：Wait 把原交易换回 TxHash 并确认，只付一次
+func TestReplaceOldTransactionMined(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+	backend := &flakyBackend{Backend: h.Client}
+	rows := []Row{{Line: 2, Recipient: h.Accounts[1], Amount: "1"}}
+	before := balance(t, h, h.Accounts[1])
+
+	p := openPayer(t, h, backend, filepath.Join(t.TempDir(), "state.json"))
+	prepare(t, p, rows)
+	if err := p.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	e := p.state.Entries[0]
+	old := e.TxHash
+	e.SentAt = time.Now().Add(-time.Hour).Unix()
+	p.MaxWait = time.Minute
+	wait(t, p)
+	if e.Status != StatusStuck {
+		t.Fatalf("status %s, want stuck", e.Status)
+	}
+
+	// 替换交易没有传播出去，原交易仍在交易池中
+	backend.drop = true
+	n, err := p.Replace(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("replaced %d, want 1", n)
+	}
+	replacement := e.TxHash
+	if replacement == old {
+		t.Fatal("replacement has the same hash as the original transaction")
+	}
+	backend.drop = false
+	h.Commit()
+	wait(t, p)
+
+	if e.Status != StatusConfirmed {
+		t.Fatalf("status %s (%s), want confirmed", e.Status, e.Error)
+	}
+	if e.TxHash != old {
+		t.Errorf("tx %s, want original %s", e.TxHash.Hex(), old.Hex())
+	}
+	if len(e.Replaced) != 1 || e.Replaced[0] != replacement {
+		t.Errorf("replaced %v, want [%s]", e.Replaced, replacement.Hex())
+	}
+	if got, want := balance(t, h, h.Accounts[1]), new(big.Int).Add(before, ether(1)); got.Cmp(want) != 0 {
+		t.Errorf("recipient balance %s, want %s", got, want)
+	}
+}
+
+// 节点拒绝重发已签名的交易：标记为 stuck 而不是让每次运行都失败，Replace 之后正常上链
+func TestResendRejectedMarkedStuck(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+	backend := &flakyBackend{Backend: h.Client}
+	path := filepath.Join(t.TempDir(), "state.json")
+	rows := []Row{{Line: 2, Recipient: h.Accounts[1], Amount: "1"}}
+
+	backend.reject = errors.New("transaction underpriced")
+	p := openPayer(t, h, backend, path)
+	prepare(t, p, rows)
+	if err := p.Send(ctx); err == nil {
+		t.Fatal("send succeeded, want broadcast error")
+	}
+
+	p = openPayer(t, h, backend, path)
+	prepare(t, p, rows)
+	if err := p.Send(ctx); err != nil {
+		t.Fatalf("send after rejected resend: %v", err)
+	}
+	e := p.state.Entries[0]
+	if e.Status != StatusStuck || e.Error == "" {
+		t.Fatalf("status %s (%q), want stuck with error", e.Status, e.Error)
+	}
+
+	backend.reject = nil
+	if n, err := p.Replace(ctx); err != nil || n != 1 {
+		t.Fatalf("replace: %d, %v", n, err)
+	}
+	h.Commit()
+	wait(t, p)
+	if e.Status != StatusConfirmed {
+		t.Fatalf("status %s (%s), want confirmed", e.Status, e.Error)
+	}
+	if n := nonce(t, h); n != 1 {
+		t.Errorf("sender nonce %d, want 1", n)
+	}
+}
+
+func TestPrepareRejectsChangedRows(t *testing.T) {
+	h := newHarness(t)
+	rows := []Row{
+		{Line: 2, Recipient: h.Accounts[1], Amount: "1"},
+		{Line: 3, Recipient: h.Accounts[2], Amount: "2"},
+	}
+	tests := []struct {
+		name      string
+		confirmed bool // 第 3 行已确认
+		rows      []Row
+		err       error
+	}{
+		{"unchanged", false, rows, nil},
+		{"amount changed", false, []Row{rows[0], {Line: 3, Recipient: h.Accounts[2], Amount: "3"}}, ErrRowChanged},
+		{"recipient changed", false, []Row{rows[0], {Line: 3, Recipient: h.Accounts[1], Amount: "2"}}, ErrRowChanged},
+		{"pending line removed", false, rows[:1], ErrRowChanged},
+		{"confirmed line removed", true, rows[:1], nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "state.json")
+			p := openPayer(t, h, h.Client, path)
+			if err := p.Prepare(ctx, rows); err != nil {
+				t.Fatal(err)
+			}
+			if tt.confirmed {
+				p.state.Entries[1].Status = StatusConfirmed
+			}
+			if err := p.state.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			p = openPayer(t, h, h.Client, path)
+			err := p.Prepare(ctx, tt.rows)
+			if tt.err == nil && err != nil {
+				t.Fatalf("prepare: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("prepare: %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
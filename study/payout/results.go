@@ -0,0 +1,54 @@
+package payout
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// WriteResults 输出每笔付款的结果 CSV，按行号排列
+func WriteResults(w io.Writer, entries []*Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"line", "recipient", "token", "amount", "value", "status", "nonce", "tx_hash", "block", "error", "replaced"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		record := []string{strconv.Itoa(e.Line), e.Recipient.Hex(), "ETH", e.Amount, e.Value.String(), string(e.Status), "", "", "", e.Error, ""}
+		if e.Token != nil {
+			record[2] = e.Token.Hex()
+		}
+		if e.Status != StatusPending {
+			record[6] = strconv.FormatUint(e.Nonce, 10)
+			record[7] = e.TxHash.Hex()
+		}
+		if e.Block > 0 {
+			record[8] = strconv.FormatUint(e.Block, 10)
+		}
+		// 被替换掉的交易哈希，用 ; 分隔
+		replaced := make([]string, len(e.Replaced))
+		for i, hash := range e.Replaced {
+			replaced[i] = hash.Hex()
+		}
+		record[10] = strings.Join(replaced, ";")
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SaveResults 把结果 CSV 写入文件
+func SaveResults(path string, entries []*Entry) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteResults(f, entries); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
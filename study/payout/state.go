@@ -0,0 +1,148 @@
+package payout
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrRowChanged CSV 中某行的内容与状态文件中记录的不一致，继续执行可能重复付款或漏付
+var ErrRowChanged = errors.New("payout row changed since state file was written")
+
+// Status 一笔付款的进度
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 尚未签名
+	StatusSigned    Status = "signed"    // 已签名并写入状态文件，不确定是否已广播
+	StatusSent      Status = "sent"      // 节点已接受交易，等待上链
+	StatusStuck     Status = "stuck"     // 超过 Payer.MaxWait 仍未上链，可用 Payer.Replace 提高手续费重新发送
+	StatusConfirmed Status = "confirmed" // 已上链、执行成功并达到确认数
+	StatusFailed    Status = "failed"    // 上链后回滚，或 nonce 已被其他交易占用，需要人工处理
+)
+
+// Entry 状态文件中的一笔付款
+// 交易签名后先写入 Nonce、TxHash 和 RawTx 再广播，崩溃后重新运行时只会重发同一笔已签名交易，不会重复付款
+type Entry struct {
+	Line      int             `json:"line"`
+	Recipient common.Address  `json:"recipient"`
+	Token     *common.Address `json:"token,omitempty"`
+	Amount    string          `json:"amount"`
+	Value     *big.Int        `json:"value"` // 按精度换算后的最小单位
+	Status    Status          `json:"status"`
+	Gas       uint64          `json:"gas,omitempty"`
+	Nonce     uint64          `json:"nonce,omitempty"`
+	TxHash    common.Hash     `json:"txHash,omitempty"`
+	RawTx     hexutil.Bytes   `json:"rawTx,omitempty"`
+	SentAt    int64           `json:"sentAt,omitempty"`   // 最近一次广播新交易的 Unix 时间（秒）
+	Replaced  []common.Hash   `json:"replaced,omitempty"` // 被 Replace 替换掉的交易，nonce 与 TxHash 相同，仍可能先于替换交易上链
+	Block     uint64          `json:"block,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Asset 付款的资产，ETH 为零地址
+func (e *Entry) Asset() common.Address {
+	if e.Token == nil {
+		return common.Address{}
+	}
+	return *e.Token
+}
+
+func (e *Entry) matches(row Row) bool {
+	return e.Recipient == row.Recipient && e.Amount == row.Amount && e.Asset() == row.Asset()
+}
+
+// State 一次批量付款的进度，每次变化后由 Save 写回文件
+type State struct {
+	Sender  common.Address `json:"sender"`
+	ChainID *big.Int       `json:"chainId"`
+	Entries []*Entry       `json:"entries"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// OpenState 读取状态文件，文件不存在时创建新的状态（在第一次 Save 时写入）
+// 文件中的发送方或链 ID 与参数不同时返回错误，避免用错账户或网络继续上次的付款
+func OpenState(path string, sender common.Address, chainID *big.Int) (*State, error) {
+	s := &State{Sender: sender, ChainID: chainID, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if s.Sender != sender {
+		return nil, fmt.Errorf("%s was written by sender %s, not %s", path, s.Sender.Hex(), sender.Hex())
+	}
+	if s.ChainID == nil || s.ChainID.Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("%s was written for chain %v, not %s", path, s.ChainID, chainID)
+	}
+	return s, nil
+}
+
+// Path 状态文件路径
+func (s *State) Path() string {
+	return s.path
+}
+
+// Save 原子地写回状态文件：先写临时文件并落盘，再重命名覆盖
+func (s *State) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// lookup 按行号找到 row 对应的记录，没有时返回 nil；行号相同但内容不同时返回 ErrRowChanged
+func (s *State) lookup(row Row) (*Entry, error) {
+	for _, e := range s.Entries {
+		if e.Line != row.Line {
+			continue
+		}
+		if !e.matches(row) {
+			return nil, fmt.Errorf("%w: line %d was %s %s, now %s %s", ErrRowChanged, row.Line, e.Recipient.Hex(), e.Amount, row.Recipient.Hex(), row.Amount)
+		}
+		return e, nil
+	}
+	return nil, nil
+}
+
+// Count 各状态的付款笔数
+func (s *State) Count() map[Status]int {
+	counts := make(map[Status]int)
+	for _, e := range s.Entries {
+		counts[e.Status]++
+	}
+	return counts
+}
@@ -0,0 +1,123 @@
+// permit_simulated.go - 在离线模拟链上演示 EIP-2612 免 gas 授权
+// 部署 GovernanceToken（OP）并给持有人铸币，持有人离线签署 permit，由中继账户提交 permit 和 transferFrom
+// 用法：go run study/permit_simulated.go
+package main
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/signer"
+	"eth-client-study/study/simulated"
+	"eth-client-study/study/tokens"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func main() {
+	// 账户 0 部署并铸币，账户 1 为持有人（只签名，不发送交易），账户 2 为中继
+	harness, err := simulated.NewHarness(3)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer harness.Close()
+	ctx := context.Background()
+
+	address, instance, err := harness.DeployPermitToken()
+	if err != nil {
+		log.Fatal("部署代币失败：", err)
+	}
+	erc20, err := tokens.NewToken(ctx, address, harness.Client)
+	if err != nil {
+		log.Fatal("读取代币信息失败：", err)
+	}
+	opts, err := harness.TransactOpts(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	amount, err := erc20.ParseAmount("100")
+	if err != nil {
+		log.Fatal(err)
+	}
+	owner := signer.NewKeySigner(harness.Keys[1])
+	tx, err := instance.Mint(opts, owner.Address(), amount)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := harness.WaitMined(tx); err != nil {
+		log.Fatal("铸币失败：", err)
+	}
+	fmt.Printf("代币 %s 已部署，%s 持有 %s\n", address.Hex(), owner.Address().Hex(), erc20.Format(amount))
+
+	relayer := signer.NewKeySigner(harness.Keys[2])
+	value, err := erc20.ParseAmount("10")
+	if err != nil {
+		log.Fatal(err)
+	}
+	deadline := big.NewInt(time.Now().Add(30 * time.Minute).Unix())
+	permit, err := erc20.SignPermit(ctx, owner, harness.ChainID, relayer.Address(), value, deadline)
+	if err != nil {
+		log.Fatal("签名失败：", err)
+	}
+	if err := erc20.VerifyPermit(ctx, permit); err != nil {
+		log.Fatal("本地验证失败：", err)
+	}
+	fmt.Printf("EIP-712 域：name=%q version=%q chainId=%s\n", permit.Name, permit.Version, permit.ChainID)
+	fmt.Printf("permit：nonce=%s 签名=%s\n", permit.Nonce, hexutil.Encode(permit.Sig))
+
+	// 模拟链不会自动出块，先由中继提交 permit 并出块；额度生效后 RelayTransfer 跳过 permit，只发送 transferFrom
+	relayOpts, err := relayer.TransactOpts(harness.ChainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tx, err = erc20.SubmitPermit(relayOpts, permit)
+	if err != nil {
+		log.Fatal("提交 permit 失败：", err)
+	}
+	if _, err := harness.WaitMined(tx); err != nil {
+		log.Fatal("permit 上链失败：", err)
+	}
+	allowance, err := erc20.Allowance(ctx, owner.Address(), relayer.Address())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("permit 已上链，中继的额度：%s\n", erc20.Format(allowance))
+
+	recipient := harness.Accounts[0]
+	txs, err := erc20.RelayTransfer(relayOpts, harness.Client, permit, recipient, value)
+	if err != nil {
+		log.Fatal("中继失败：", err)
+	}
+	receipt, err := harness.WaitMined(txs[len(txs)-1])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := erc20.VerifyTransfer(receipt, owner.Address(), recipient, value); err != nil {
+		log.Fatal("校验 Transfer 事件失败：", err)
+	}
+	balance, err := erc20.BalanceOf(ctx, owner.Address())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("transferFrom 已上链：区块 %d，持有人余额 %s\n", receipt.BlockNumber, erc20.Format(balance))
+
+	// 同一个签名不能重放：nonce 已经递增
+	if err := erc20.VerifyPermit(ctx, permit); errors.Is(err, tokens.ErrPermitNonce) {
+		fmt.Println("重放同一签名被拒绝：", err)
+	} else {
+		log.Fatal("重放检查不符合预期：", err)
+	}
+	// 过期的签名在本地即被拒绝
+	expired, err := erc20.SignPermit(ctx, owner, harness.ChainID, relayer.Address(), value, big.NewInt(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := erc20.VerifyPermit(ctx, expired); errors.Is(err, tokens.ErrPermitExpired) {
+		fmt.Println("过期签名被拒绝：", err)
+	} else {
+		log.Fatal("过期检查不符合预期：", err)
+	}
+}
@@ -0,0 +1,103 @@
+// permit_transfer.go - EIP-2612 免 gas 授权：持有人离线签署 permit，由中继账户提交 permit 和 transferFrom
+// 持有人私钥为 PRIVATE_KEY1，中继私钥为 PRIVATE_KEY2，持有人账户不需要 ETH
+// 用法：
+//
+//	go run study/permit_transfer.go -token 0x... -to bob -amount 10
+//	go run study/permit_transfer.go -token 0x... -amount 10 -sign-only
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/signer"
+	"eth-client-study/study/tokens"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	tokenFlag := flag.String("token", "", "支持 EIP-2612 的 ERC20 代币地址或地址簿名称")
+	toFlag := flag.String("to", "carol", "接收地址或地址簿名称")
+	amountFlag := flag.String("amount", "1", "授权并转账的金额，按代币精度解析")
+	deadlineFlag := flag.Duration("deadline", 30*time.Minute, "签名有效期")
+	signOnlyFlag := flag.Bool("sign-only", false, "只签名并在本地验证，不提交交易")
+	flag.Parse()
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	book := addressbook.MustOpen(ctx, client)
+
+	owner, err := signer.FromEnv("PRIVATE_KEY1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	relayer, err := signer.FromEnv("PRIVATE_KEY2")
+	if err != nil {
+		log.Fatal(err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	erc20, err := tokens.NewToken(ctx, book.MustResolve("-token", *tokenFlag), client)
+	if err != nil {
+		log.Fatal("读取代币信息失败：", err)
+	}
+	if erc20.Quirks.Has(tokens.QuirkNoDecimals) || erc20.Quirks.Has(tokens.QuirkBadDecimals) {
+		log.Fatalf("代币没有有效的 decimals()（%s），无法确定金额精度", erc20.Quirks)
+	}
+	amount, err := erc20.ParseAmount(*amountFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	deadline := big.NewInt(time.Now().Add(*deadlineFlag).Unix())
+	permit, err := erc20.SignPermit(ctx, owner, chainID, relayer.Address(), amount, deadline)
+	if err != nil {
+		log.Fatal("签名失败：", err)
+	}
+	if err := erc20.VerifyPermit(ctx, permit); err != nil {
+		log.Fatal("本地验证失败：", err)
+	}
+	v, r, s := permit.VRS()
+	fmt.Printf("EIP-712 域：name=%q version=%q chainId=%s\n", permit.Name, permit.Version, permit.ChainID)
+	fmt.Printf("permit：owner=%s spender=%s value=%s nonce=%s deadline=%s\n", permit.Owner.Hex(), permit.Spender.Hex(), erc20.Format(permit.Value), permit.Nonce, permit.Deadline)
+	fmt.Printf("签名：%s\nv=%d r=%s s=%s\n", hexutil.Encode(permit.Sig), v, hexutil.Encode(r[:]), hexutil.Encode(s[:]))
+	if *signOnlyFlag {
+		return
+	}
+
+	opts, err := relayer.TransactOpts(chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts.Context = ctx
+	toAddress := book.MustResolve("-to", *toFlag)
+	txs, err := erc20.RelayTransfer(opts, client, permit, toAddress, amount)
+	for _, tx := range txs {
+		fmt.Println("已发送交易：", tx.Hash().Hex())
+	}
+	if err != nil {
+		log.Fatal("中继失败：", err)
+	}
+	last := txs[len(txs)-1]
+	receipt, err := bind.WaitMined(ctx, client, last)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := erc20.VerifyTransfer(receipt, permit.Owner, toAddress, amount); err != nil {
+		log.Fatal("校验 Transfer 事件失败：", err)
+	}
+	fmt.Printf("已上链：区块 %d，%s 从 %s 转到 %s\n", receipt.BlockNumber, erc20.Format(amount), permit.Owner.Hex(), toAddress.Hex())
+}
@@ -0,0 +1,95 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// 支持的输出格式
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// Write 按指定格式输出资产
+func Write(w io.Writer, p *Portfolio, format string) error {
+	switch format {
+	case FormatTable, "":
+		return WriteTable(w, p)
+	case FormatJSON:
+		return WriteJSON(w, p)
+	case FormatCSV:
+		return WriteCSV(w, p)
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// WriteTable 输出对齐的表格，查询失败的代币列在表格之后
+func WriteTable(w io.Writer, p *Portfolio) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "账户\t资产\t余额\t代币合约\t")
+	for _, h := range p.Holdings {
+		contract := "-"
+		if h.Token != nil {
+			contract = h.Token.Hex()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\n", h.Account.Hex(), h.Symbol, h.Amount(), contract)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, f := range p.Failed {
+		if _, err := fmt.Fprintf(w, "查询失败：%s %s：%v\n", f.Token.Symbol, f.Token.Address.Hex(), f.Reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON 以缩进的 JSON 输出，每项附带格式化后的 amount
+func WriteJSON(w io.Writer, p *Portfolio) error {
+	type jsonHolding struct {
+		Holding
+		Amount string `json:"amount"`
+	}
+	type jsonFailure struct {
+		Token  TokenInfo `json:"token"`
+		Reason string    `json:"reason"`
+	}
+	out := struct {
+		Holdings []jsonHolding `json:"holdings"`
+		Failed   []jsonFailure `json:"failed,omitempty"`
+	}{Holdings: make([]jsonHolding, len(p.Holdings))}
+	for i, h := range p.Holdings {
+		out.Holdings[i] = jsonHolding{Holding: h, Amount: h.Amount()}
+	}
+	for _, f := range p.Failed {
+		out.Failed = append(out.Failed, jsonFailure{Token: f.Token, Reason: f.Reason.Error()})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// WriteCSV 每个账户的每种资产输出一行，ETH 的 token 列为空
+func WriteCSV(w io.Writer, p *Portfolio) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"account", "token", "symbol", "decimals", "balance", "amount"}); err != nil {
+		return err
+	}
+	for _, h := range p.Holdings {
+		contract := ""
+		if h.Token != nil {
+			contract = h.Token.Hex()
+		}
+		if err := cw.Write([]string{h.Account.Hex(), contract, h.Symbol, fmt.Sprint(h.Decimals), h.Balance.String(), h.Amount()}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,158 @@
+package portfolio
+
+import (
+	"context"
+	"eth-client-study/study/blockref"
+	token "eth-client-study/study/erc20"
+	"eth-client-study/study/multicall"
+	"eth-client-study/study/multicall3"
+	"eth-client-study/study/tokens"
+	"eth-client-study/utils"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	symbolSelector   = crypto.Keccak256([]byte("symbol()"))[:4]
+	decimalsSelector = crypto.Keccak256([]byte("decimals()"))[:4]
+)
+
+// Holding 一个账户持有的一种资产，Token 为 nil 表示 ETH
+type Holding struct {
+	Account  common.Address  `json:"account"`
+	Token    *common.Address `json:"token,omitempty"`
+	Symbol   string          `json:"symbol"`
+	Decimals uint8           `json:"decimals"`
+	Balance  *big.Int        `json:"balance"`
+}
+
+// Amount 按精度格式化后的余额
+func (h Holding) Amount() string {
+	return utils.FormatUnits(h.Balance, h.Decimals)
+}
+
+// Failure 查询失败的代币，如地址上不是 ERC20 合约
+type Failure struct {
+	Token  TokenInfo
+	Reason error
+}
+
+// Portfolio 一组账户在同一区块的全部资产
+type Portfolio struct {
+	Holdings []Holding
+	Failed   []Failure
+}
+
+// Fetcher 通过 Multicall3 一次性查询多个账户的 ETH 和代币余额
+type Fetcher struct {
+	caller    bind.ContractCaller
+	multicall common.Address
+
+	HideZero bool // 不输出余额为 0 的资产
+}
+
+// NewFetcher 创建查询器，multicallAddress 一般为 multicall.DefaultAddress
+func NewFetcher(caller bind.ContractCaller, multicallAddress common.Address) *Fetcher {
+	return &Fetcher{caller: caller, multicall: multicallAddress, HideZero: true}
+}
+
+// Fetch 在 sel 对应的区块（零值为 latest）查询 accounts 的 ETH 和 list 中每个代币的余额
+// 符号和精度优先使用链上 symbol()/decimals() 的返回值，读取失败时使用代币列表中的值；
+// 单个代币的 balanceOf 失败不会影响其他代币，记录在 Failed 中
+func (f *Fetcher) Fetch(ctx context.Context, sel blockref.Selector, accounts []common.Address, list []TokenInfo) (*Portfolio, error) {
+	mc, err := multicall.New(f.caller, f.multicall)
+	if err != nil {
+		return nil, err
+	}
+	erc20ABI, err := token.Erc20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	type meta struct{ symbol, decimals *multicall.Result }
+	metas := make([]meta, len(list))
+	for i, t := range list {
+		metas[i] = meta{
+			symbol:   mc.AddCall(multicall.Call{Target: t.Address, AllowFailure: true, CallData: symbolSelector}),
+			decimals: mc.AddCall(multicall.Call{Target: t.Address, AllowFailure: true, CallData: decimalsSelector}),
+		}
+	}
+	ethBalances := make([]*big.Int, len(accounts))
+	tokenBalances := make([][]*multicall.Result, len(accounts))
+	for i, account := range accounts {
+		if _, err := mc.Add(false, func(caller bind.ContractCaller) error {
+			instance, err := multicall3.NewMulticall3Caller(f.multicall, caller)
+			if err != nil {
+				return err
+			}
+			ethBalances[i], err = instance.GetEthBalance(nil, account)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		tokenBalances[i] = make([]*multicall.Result, len(list))
+		for j, t := range list {
+			data, err := erc20ABI.Pack("balanceOf", account)
+			if err != nil {
+				return nil, err
+			}
+			tokenBalances[i][j] = mc.AddCall(multicall.Call{Target: t.Address, AllowFailure: true, CallData: data})
+		}
+	}
+	if err := mc.Execute(ctx, sel); err != nil {
+		return nil, err
+	}
+
+	p := new(Portfolio)
+	failed := make([]bool, len(list))
+	for i, account := range accounts {
+		if !f.HideZero || ethBalances[i].Sign() > 0 {
+			p.Holdings = append(p.Holdings, Holding{Account: account, Symbol: "ETH", Decimals: 18, Balance: ethBalances[i]})
+		}
+		for j, t := range list {
+			result := tokenBalances[i][j]
+			balance, err := decodeBalance(result)
+			if err != nil {
+				if !failed[j] {
+					failed[j] = true
+					p.Failed = append(p.Failed, Failure{Token: t, Reason: err})
+				}
+				continue
+			}
+			if f.HideZero && balance.Sign() == 0 {
+				continue
+			}
+			address := t.Address
+			holding := Holding{Account: account, Token: &address, Symbol: t.Symbol, Decimals: t.Decimals, Balance: balance}
+			if metas[j].symbol.Success {
+				if symbol, _, err := tokens.DecodeText(metas[j].symbol.ReturnData); err == nil && symbol != "" {
+					holding.Symbol = symbol
+				}
+			}
+			if metas[j].decimals.Success {
+				if decimals, err := tokens.DecodeDecimals(metas[j].decimals.ReturnData); err == nil {
+					holding.Decimals = decimals
+				}
+			}
+			p.Holdings = append(p.Holdings, holding)
+		}
+	}
+	return p, nil
+}
+
+func decodeBalance(result *multicall.Result) (*big.Int, error) {
+	if !result.Success {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return nil, multicall.ErrCallFailed
+	}
+	if len(result.ReturnData) != 32 {
+		return nil, fmt.Errorf("balanceOf returned %d bytes", len(result.ReturnData))
+	}
+	return new(big.Int).SetBytes(result.ReturnData), nil
+}
@@ -0,0 +1,97 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"eth-client-study/utils"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenInfo 代币列表中的一项，字段与 https://tokenlists.org 的 schema 一致
+type TokenInfo struct {
+	ChainID  uint64         `json:"chainId"`
+	Address  common.Address `json:"address"`
+	Name     string         `json:"name"`
+	Symbol   string         `json:"symbol"`
+	Decimals uint8          `json:"decimals"`
+	LogoURI  string         `json:"logoURI,omitempty"`
+	Tags     []string       `json:"tags,omitempty"`
+}
+
+// TokenList Uniswap 风格的代币列表
+type TokenList struct {
+	Name      string      `json:"name"`
+	Timestamp string      `json:"timestamp"`
+	Version   Version     `json:"version"`
+	Tokens    []TokenInfo `json:"tokens"`
+}
+
+// Version 代币列表的语义化版本号
+type Version struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// ReadTokenList 解析代币列表 JSON，地址格式或校验和错误（见 utils.ParseAddress）、同一条链上地址重复时返回错误
+func ReadTokenList(r io.Reader) (*TokenList, error) {
+	var raw struct {
+		TokenList
+		Tokens []struct {
+			TokenInfo
+			Address string `json:"address"`
+		} `json:"tokens"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	list := raw.TokenList
+	list.Tokens = make([]TokenInfo, 0, len(raw.Tokens))
+	type key struct {
+		chainID uint64
+		address common.Address
+	}
+	seen := make(map[key]bool)
+	for i, t := range raw.Tokens {
+		address, err := utils.ParseAddress(t.Address)
+		if err != nil {
+			return nil, fmt.Errorf("token %d (%s): %w", i, t.Symbol, err)
+		}
+		info := t.TokenInfo
+		info.Address = address
+		if seen[key{info.ChainID, info.Address}] {
+			return nil, fmt.Errorf("token %d (%s): duplicate address %s on chain %d", i, t.Symbol, info.Address.Hex(), info.ChainID)
+		}
+		seen[key{info.ChainID, info.Address}] = true
+		list.Tokens = append(list.Tokens, info)
+	}
+	return &list, nil
+}
+
+// LoadTokenList 从本地文件读取代币列表
+func LoadTokenList(path string) (*TokenList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadTokenList(f)
+}
+
+// ForChain 列表中属于 chainID 的代币，保持原有顺序
+func (l *TokenList) ForChain(chainID uint64) []TokenInfo {
+	var tokens []TokenInfo
+	for _, t := range l.Tokens {
+		if t.ChainID == chainID {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,246 @@
+package proof
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"eth-client-study/study/blockref"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	// ErrHeaderHash 区块头字段重新计算出的哈希与节点返回的哈希不一致
+	ErrHeaderHash = errors.New("header hash mismatch")
+	// ErrTxRoot 交易树根与区块头中的 TxHash 不一致
+	ErrTxRoot = errors.New("transactions root mismatch")
+	// ErrReceiptRoot 收据树根与区块头中的 ReceiptHash 不一致
+	ErrReceiptRoot = errors.New("receipts root mismatch")
+)
+
+// VerifyTransactions 重新计算交易树根并与区块头比较
+func VerifyTransactions(header *types.Header, txs types.Transactions) error {
+	if root := types.DeriveSha(txs, trie.NewStackTrie(nil)); root != header.TxHash {
+		return fmt.Errorf("%w: computed %s, header %s", ErrTxRoot, root.Hex(), header.TxHash.Hex())
+	}
+	return nil
+}
+
+// VerifyReceipts 重新计算收据树根并与区块头比较，同时检查收据属于该区块且顺序正确
+// 收据树只包含共识字段（类型、状态、累计 gas、布隆过滤器、日志），gasUsed、合约地址等字段不受其保护
+func VerifyReceipts(header *types.Header, receipts types.Receipts) error {
+	hash := header.Hash()
+	for i, receipt := range receipts {
+		if receipt.BlockHash != (common.Hash{}) && receipt.BlockHash != hash {
+			return fmt.Errorf("receipt %d belongs to block %s, want %s", i, receipt.BlockHash.Hex(), hash.Hex())
+		}
+		if receipt.TransactionIndex != uint(i) {
+			return fmt.Errorf("receipt %d has transaction index %d", i, receipt.TransactionIndex)
+		}
+	}
+	if root := types.DeriveSha(receipts, trie.NewStackTrie(nil)); root != header.ReceiptHash {
+		return fmt.Errorf("%w: computed %s, header %s", ErrReceiptRoot, root.Hex(), header.ReceiptHash.Hex())
+	}
+	return nil
+}
+
+// VerifiedBlock 已验证的区块：区块头哈希、交易树根、收据树根均与区块头一致
+type VerifiedBlock struct {
+	Block    *types.Block
+	Receipts types.Receipts
+}
+
+// FetchBlock 读取区块及其全部收据并验证
+// 区块头哈希由本地根据字段重新计算，与节点返回的 hash 比较；按哈希选择时还会与请求的哈希比较
+func FetchBlock(ctx context.Context, client *rpc.Client, sel blockref.Selector) (*VerifiedBlock, error) {
+	method, arg := "eth_getBlockByNumber", interface{}(sel)
+	if hash, ok := sel.BlockHash(); ok {
+		method, arg = "eth_getBlockByHash", hash
+	}
+	var raw json.RawMessage
+	if err := client.CallContext(ctx, &raw, method, arg, true); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, fmt.Errorf("block %s: %w", sel, ethereum.NotFound)
+	}
+	var header types.Header
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+	var body struct {
+		Hash         common.Hash          `json:"hash"`
+		Transactions []*types.Transaction `json:"transactions"`
+		Withdrawals  []*types.Withdrawal  `json:"withdrawals"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, err
+	}
+	if err := VerifyHeader(&header, body.Hash); err != nil {
+		return nil, err
+	}
+	if hash, ok := sel.BlockHash(); ok && hash != body.Hash {
+		return nil, fmt.Errorf("%w: got block %s, requested %s", ErrHeaderHash, body.Hash.Hex(), hash.Hex())
+	}
+	if err := VerifyTransactions(&header, body.Transactions); err != nil {
+		return nil, err
+	}
+
+	var receipts types.Receipts
+	if err := client.CallContext(ctx, &receipts, "eth_getBlockReceipts", blockref.Hash(body.Hash, false)); err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(body.Transactions) {
+		return nil, fmt.Errorf("%d receipts for %d transactions", len(receipts), len(body.Transactions))
+	}
+	for i, receipt := range receipts {
+		if receipt.TxHash != body.Transactions[i].Hash() {
+			return nil, fmt.Errorf("receipt %d is for transaction %s, want %s", i, receipt.TxHash.Hex(), body.Transactions[i].Hash().Hex())
+		}
+	}
+	if err := VerifyReceipts(&header, receipts); err != nil {
+		return nil, err
+	}
+	block := types.NewBlockWithHeader(&header).WithBody(types.Body{Transactions: body.Transactions, Withdrawals: body.Withdrawals})
+	return &VerifiedBlock{Block: block, Receipts: receipts}, nil
+}
+
+// VerifyHeader 根据区块头字段重新计算哈希并与期望值比较
+// 不一致通常说明节点篡改了字段，或本地 go-ethereum 版本不认识新分叉引入的区块头字段
+func VerifyHeader(header *types.Header, want common.Hash) error {
+	if got := header.Hash(); got != want {
+		return fmt.Errorf("%w: computed %s, reported %s", ErrHeaderHash, got.Hex(), want.Hex())
+	}
+	return nil
+}
+
+// ReceiptProof 某笔收据在收据树中的包含证明，可脱离节点单独验证
+type ReceiptProof struct {
+	BlockHash    common.Hash     `json:"blockHash"`
+	ReceiptsRoot common.Hash     `json:"receiptsRoot"`
+	Index        uint64          `json:"transactionIndex"`
+	Proof        []hexutil.Bytes `json:"proof"`
+}
+
+// LogProof 某条日志的包含证明：所在收据的证明加上日志在收据中的下标
+type LogProof struct {
+	ReceiptProof
+	LogIndex uint `json:"logIndexInReceipt"`
+}
+
+// BuildReceiptProof 为区块中第 index 笔收据生成包含证明
+func BuildReceiptProof(header *types.Header, receipts types.Receipts, index uint64) (*ReceiptProof, error) {
+	if index >= uint64(len(receipts)) {
+		return nil, fmt.Errorf("receipt index %d out of range [0, %d)", index, len(receipts))
+	}
+	root, nodes, err := buildProof(receipts, index)
+	if err != nil {
+		return nil, err
+	}
+	if root != header.ReceiptHash {
+		return nil, fmt.Errorf("%w: computed %s, header %s", ErrReceiptRoot, root.Hex(), header.ReceiptHash.Hex())
+	}
+	return &ReceiptProof{BlockHash: header.Hash(), ReceiptsRoot: root, Index: index, Proof: nodes}, nil
+}
+
+// BuildLogProof 为区块中第 txIndex 笔交易产生的第 logIndex 条日志生成包含证明
+func BuildLogProof(header *types.Header, receipts types.Receipts, txIndex uint64, logIndex uint) (*LogProof, error) {
+	p, err := BuildReceiptProof(header, receipts, txIndex)
+	if err != nil {
+		return nil, err
+	}
+	if logIndex >= uint(len(receipts[txIndex].Logs)) {
+		return nil, fmt.Errorf("log index %d out of range [0, %d)", logIndex, len(receipts[txIndex].Logs))
+	}
+	return &LogProof{ReceiptProof: *p, LogIndex: logIndex}, nil
+}
+
+// VerifyReceiptProof 用可信的收据树根验证证明，返回证明中的收据（仅包含共识字段）
+func VerifyReceiptProof(receiptsRoot common.Hash, p *ReceiptProof) (*types.Receipt, error) {
+	if p.ReceiptsRoot != receiptsRoot {
+		return nil, fmt.Errorf("%w: proof root %s, trusted %s", ErrReceiptRoot, p.ReceiptsRoot.Hex(), receiptsRoot.Hex())
+	}
+	nodes := make([][]byte, len(p.Proof))
+	for i, node := range p.Proof {
+		nodes[i] = node
+	}
+	value, err := trie.VerifyProof(receiptsRoot, rlp.AppendUint64(nil, p.Index), proofDB(nodes))
+	if err != nil {
+		return nil, fmt.Errorf("receipt proof for index %d: %w", p.Index, err)
+	}
+	if len(value) == 0 {
+		return nil, fmt.Errorf("receipt %d not in receipts trie", p.Index)
+	}
+	receipt := new(types.Receipt)
+	if err := receipt.UnmarshalBinary(value); err != nil {
+		return nil, fmt.Errorf("decode receipt %d: %w", p.Index, err)
+	}
+	return receipt, nil
+}
+
+// VerifyLogProof 用可信的收据树根验证日志证明，返回证明中的日志（包含地址、主题和数据）
+func VerifyLogProof(receiptsRoot common.Hash, p *LogProof) (*types.Log, error) {
+	receipt, err := VerifyReceiptProof(receiptsRoot, &p.ReceiptProof)
+	if err != nil {
+		return nil, err
+	}
+	if p.LogIndex >= uint(len(receipt.Logs)) {
+		return nil, fmt.Errorf("log index %d out of range, receipt has %d logs", p.LogIndex, len(receipt.Logs))
+	}
+	log := receipt.Logs[p.LogIndex]
+	log.BlockHash = p.BlockHash
+	log.TxIndex = uint(p.Index)
+	return log, nil
+}
+
+// MatchLog 比较节点返回的日志与证明中的日志的共识字段
+func MatchLog(proven, reported *types.Log) bool {
+	if proven.Address != reported.Address || !bytes.Equal(proven.Data, reported.Data) || len(proven.Topics) != len(reported.Topics) {
+		return false
+	}
+	for i := range proven.Topics {
+		if proven.Topics[i] != reported.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// buildProof 构建列表的 Merkle Patricia 树（key 为 RLP 编码的下标）并生成 index 的证明
+func buildProof(list types.DerivableList, index uint64) (common.Hash, []hexutil.Bytes, error) {
+	tr := trie.NewEmpty(nil)
+	var buf bytes.Buffer
+	for i := 0; i < list.Len(); i++ {
+		buf.Reset()
+		list.EncodeIndex(i, &buf)
+		if err := tr.Update(rlp.AppendUint64(nil, uint64(i)), common.CopyBytes(buf.Bytes())); err != nil {
+			return common.Hash{}, nil, err
+		}
+	}
+	root := tr.Hash()
+	var nodes nodeList
+	if err := tr.Prove(rlp.AppendUint64(nil, index), &nodes); err != nil {
+		return common.Hash{}, nil, err
+	}
+	return root, nodes, nil
+}
+
+// nodeList 按顺序收集 trie.Prove 输出的证明节点
+type nodeList []hexutil.Bytes
+
+func (n *nodeList) Put(key []byte, value []byte) error {
+	*n = append(*n, common.CopyBytes(value))
+	return nil
+}
+
+func (n *nodeList) Delete(key []byte) error {
+	return errors.New("not supported")
+}
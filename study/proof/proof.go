@@ -0,0 +1,95 @@
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"eth-client-study/study/storage"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// ErrMismatch 节点返回的字段与 Merkle 证明中的值不一致，说明节点数据不可信
+var ErrMismatch = errors.New("proof does not match reported value")
+
+// proofDB 把证明节点按 keccak256(node) 存入内存数据库，供 trie.VerifyProof 查找
+func proofDB(nodes [][]byte) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range nodes {
+		db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
+
+// VerifyAccountProof 用状态根验证账户证明，返回证明中的账户
+// 账户不存在时证明是一个“不存在证明”，返回 nonce、余额为 0 且 codeHash、storageRoot 为空值的账户
+func VerifyAccountProof(stateRoot common.Hash, address common.Address, nodes [][]byte) (*types.StateAccount, error) {
+	value, err := trie.VerifyProof(stateRoot, crypto.Keccak256(address.Bytes()), proofDB(nodes))
+	if err != nil {
+		return nil, fmt.Errorf("account proof for %s: %w", address.Hex(), err)
+	}
+	if len(value) == 0 {
+		return types.NewEmptyStateAccount(), nil
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return nil, fmt.Errorf("decode account %s: %w", address.Hex(), err)
+	}
+	return &account, nil
+}
+
+// VerifyStorageProof 用账户的存储根验证存储槽证明，返回槽中的 32 字节值（不存在的槽为 0）
+func VerifyStorageProof(storageRoot common.Hash, slot common.Hash, nodes [][]byte) (common.Hash, error) {
+	if storageRoot == types.EmptyRootHash && len(nodes) == 0 {
+		return common.Hash{}, nil
+	}
+	value, err := trie.VerifyProof(storageRoot, crypto.Keccak256(slot.Bytes()), proofDB(nodes))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("storage proof for slot %s: %w", slot.Hex(), err)
+	}
+	if len(value) == 0 {
+		return common.Hash{}, nil
+	}
+	// 存储树中的值是去掉前导零后再 RLP 编码的字节串
+	_, content, _, err := rlp.Split(value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("decode slot %s: %w", slot.Hex(), err)
+	}
+	return common.BytesToHash(content), nil
+}
+
+// StoreItemsSlot Store 合约中 items 映射所在的槽位（version 占用槽 0）
+const StoreItemsSlot = 1
+
+// StoreItemSlot 计算 Store.items[key] 的存储槽，见 storage.MappingSlot
+func StoreItemSlot(key [32]byte) common.Hash {
+	return storage.MappingSlot(key[:], common.BigToHash(big.NewInt(StoreItemsSlot)))
+}
+
+// checkAccount 比较节点返回的账户字段与证明中的账户，balance 不能为 nil（由 VerifyAccount 检查）
+func checkAccount(account *types.StateAccount, balance *big.Int, nonce uint64, codeHash, storageRoot common.Hash) error {
+	// geth 对不存在的账户返回全零的 codeHash 和 storageHash，等价于空代码哈希和空树根
+	if codeHash == (common.Hash{}) {
+		codeHash = types.EmptyCodeHash
+	}
+	if storageRoot == (common.Hash{}) {
+		storageRoot = types.EmptyRootHash
+	}
+	switch {
+	case account.Balance.ToBig().Cmp(balance) != 0:
+		return fmt.Errorf("%w: balance %s, proof %s", ErrMismatch, balance, account.Balance)
+	case account.Nonce != nonce:
+		return fmt.Errorf("%w: nonce %d, proof %d", ErrMismatch, nonce, account.Nonce)
+	case !bytes.Equal(account.CodeHash, codeHash.Bytes()):
+		return fmt.Errorf("%w: codeHash %s, proof %x", ErrMismatch, codeHash.Hex(), account.CodeHash)
+	case account.Root != storageRoot:
+		return fmt.Errorf("%w: storageHash %s, proof %s", ErrMismatch, storageRoot.Hex(), account.Root.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package proof
+
+import (
+	"context"
+	"eth-client-study/study/blockref"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// AccountResult eth_getProof 的返回值（EIP-1186）
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// StorageResult 单个存储槽的证明
+type StorageResult struct {
+	Key   hexutil.Bytes   `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// Account 经过 Merkle 证明验证的账户状态
+type Account struct {
+	Address     common.Address
+	Header      *types.Header // 证明所依据的区块头，其 Root 为状态根
+	Balance     *big.Int
+	Nonce       uint64
+	CodeHash    common.Hash
+	StorageRoot common.Hash
+	Storage     map[common.Hash]common.Hash // 存储槽 -> 已验证的值
+}
+
+// Verifier 通过 eth_getProof 读取并在本地验证账户和存储
+// 验证只保证数据与区块头的状态根一致，区块头本身需要可信：按哈希选择时会校验区块头哈希，
+// 因此传入来自可信来源（如自己的轻客户端或另一个节点）的区块哈希即可得到信任最小化的读取结果
+type Verifier struct {
+	client *rpc.Client
+	reader *blockref.Reader
+}
+
+// NewVerifier 创建验证器
+func NewVerifier(client *rpc.Client) *Verifier {
+	return &Verifier{client: client, reader: blockref.NewReader(client)}
+}
+
+// Header 读取区块头，按哈希选择时校验区块头内容确实对应该哈希
+func (v *Verifier) Header(ctx context.Context, sel blockref.Selector) (*types.Header, error) {
+	header, err := v.reader.HeaderAt(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+	if hash, ok := sel.BlockHash(); ok && header.Hash() != hash {
+		return nil, fmt.Errorf("header hash %s does not match requested %s", header.Hash().Hex(), hash.Hex())
+	}
+	return header, nil
+}
+
+// GetAccount 读取并验证 sel 区块上的账户及指定存储槽
+// 先取区块头，再按该区块的哈希请求证明，保证证明与状态根来自同一个区块
+func (v *Verifier) GetAccount(ctx context.Context, address common.Address, slots []common.Hash, sel blockref.Selector) (*Account, error) {
+	header, err := v.Header(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(slots))
+	for i, slot := range slots {
+		keys[i] = slot.Hex()
+	}
+	var result AccountResult
+	if err := v.client.CallContext(ctx, &result, "eth_getProof", address, keys, blockref.Hash(header.Hash(), false)); err != nil {
+		return nil, err
+	}
+	return VerifyAccount(header, address, slots, &result)
+}
+
+// GetStoreItem 读取并验证 Store.items[key] 在 sel 区块上的值
+func (v *Verifier) GetStoreItem(ctx context.Context, store common.Address, key [32]byte, sel blockref.Selector) (common.Hash, *Account, error) {
+	slot := StoreItemSlot(key)
+	account, err := v.GetAccount(ctx, store, []common.Hash{slot}, sel)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	return account.Storage[slot], account, nil
+}
+
+// VerifyAccount 用区块头的状态根验证 eth_getProof 的结果
+func VerifyAccount(header *types.Header, address common.Address, slots []common.Hash, result *AccountResult) (*Account, error) {
+	if result.Address != address {
+		return nil, fmt.Errorf("%w: proof is for %s, want %s", ErrMismatch, result.Address.Hex(), address.Hex())
+	}
+	account, err := VerifyAccountProof(header.Root, address, toBytes(result.AccountProof))
+	if err != nil {
+		return nil, err
+	}
+	// 字段缺失时 ToInt 返回 nil，比较前先拒绝
+	if result.Balance == nil {
+		return nil, fmt.Errorf("%w: balance missing from response", ErrMismatch)
+	}
+	if err := checkAccount(account, result.Balance.ToInt(), uint64(result.Nonce), result.CodeHash, result.StorageHash); err != nil {
+		return nil, err
+	}
+	if len(result.StorageProof) != len(slots) {
+		return nil, fmt.Errorf("%w: %d storage proofs for %d slots", ErrMismatch, len(result.StorageProof), len(slots))
+	}
+	verified := &Account{
+		Address:     address,
+		Header:      header,
+		Balance:     account.Balance.ToBig(),
+		Nonce:       account.Nonce,
+		CodeHash:    common.BytesToHash(account.CodeHash),
+		StorageRoot: account.Root,
+		Storage:     make(map[common.Hash]common.Hash, len(slots)),
+	}
+	for i, slot := range slots {
+		sp := result.StorageProof[i]
+		if common.BytesToHash(sp.Key) != slot {
+			return nil, fmt.Errorf("%w: storage proof %d is for key %x, want %s", ErrMismatch, i, []byte(sp.Key), slot.Hex())
+		}
+		if sp.Value == nil {
+			return nil, fmt.Errorf("%w: slot %s value missing from response", ErrMismatch, slot.Hex())
+		}
+		value, err := VerifyStorageProof(account.Root, slot, toBytes(sp.Proof))
+		if err != nil {
+			return nil, err
+		}
+		if reported := common.BigToHash(sp.Value.ToInt()); reported != value {
+			return nil, fmt.Errorf("%w: slot %s value %s, proof %s", ErrMismatch, slot.Hex(), reported.Hex(), value.Hex())
+		}
+		verified.Storage[slot] = value
+	}
+	return verified, nil
+}
+
+func toBytes(nodes []hexutil.Bytes) [][]byte {
+	out := make([][]byte, len(nodes))
+	for i, node := range nodes {
+		out[i] = node
+	}
+	return out
+}
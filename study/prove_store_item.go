@@ -0,0 +1,63 @@
+// prove_store_item.go - 用 eth_getProof 验证 Store.items[key] 的值
+// 账户证明对照区块头中的状态根验证，存储证明对照账户的存储根验证，不需要信任节点返回的余额或存储值
+// 用法：
+//
+//	go run study/prove_store_item.go -key demo_save_key
+//	go run study/prove_store_item.go -key 0x01 -codec hex -block finalized
+//	go run study/prove_store_item.go -key demo_save_key -block 0x<可信的区块哈希>!
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/proof"
+	"eth-client-study/study/storekv"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func main() {
+	storeFlag := flag.String("store", "store", "Store 合约地址或地址簿名称")
+	keyFlag := flag.String("key", "demo_save_key", "items 的 key")
+	codecFlag := flag.String("codec", "string", "key 的编码：string、hex、uint、address、keccak")
+	blockFlag := flag.String("block", "finalized", "区块：latest、safe、finalized、区块号或区块哈希（末尾加 ! 要求在主链上）")
+	flag.Parse()
+
+	sel, err := blockref.Parse(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	codec, err := storekv.CodecByName(*codecFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	key, err := codec.Encode(*keyFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client, err := rpc.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	book := addressbook.MustOpen(context.Background(), ethclient.NewClient(client))
+	verifier := proof.NewVerifier(client)
+	value, account, err := verifier.GetStoreItem(context.Background(), book.MustResolve("-store", *storeFlag), key, sel)
+	if err != nil {
+		log.Fatal("验证失败：", err)
+	}
+
+	fmt.Printf("区块：%d %s\n", account.Header.Number.Uint64(), account.Header.Hash().Hex())
+	fmt.Printf("状态根：%s\n", account.Header.Root.Hex())
+	fmt.Printf("合约 nonce：%d，余额：%s\n", account.Nonce, account.Balance)
+	fmt.Printf("代码哈希：%s\n", account.CodeHash.Hex())
+	fmt.Printf("存储根：%s\n", account.StorageRoot.Hex())
+	fmt.Printf("存储槽：%s\n", proof.StoreItemSlot(key).Hex())
+	fmt.Printf("items[%s] = %s（已验证）\n", *keyFlag, storekv.Display(storekv.String, value))
+}
@@ -2,10 +2,11 @@ package main
 
 import (
 	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
 	"fmt"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -16,8 +17,9 @@ func main() {
 		fmt.Println("连接失败", err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 
-	account := common.HexToAddress("0x25836239F7b632635F815689389C537133248edb")
+	account := book.MustResolve("账户地址", "alice")
 	// 获取账户余额
 	balance, err := client.BalanceAt(context.Background(), account, nil)
 	if err != nil {
@@ -36,4 +38,26 @@ func main() {
 	balance, err = client.BalanceAt(context.Background(), account, big.NewInt(5532993))
 
 	fmt.Println("区块余额:", balance)
+
+	// 按确定性查询余额：safe / finalized 状态不会因链重组而改变，适合对账
+	// 默认选择器可通过 .env 中的 BLOCK_TAG 配置，例如 BLOCK_TAG=finalized
+	reader := blockref.NewReader(client.Client())
+	if reader.Default, err = blockref.DefaultFromEnv(); err != nil {
+		fmt.Println("读取默认区块配置失败", err)
+		return
+	}
+	for _, sel := range []blockref.Selector{{}, blockref.Safe, blockref.Finalized} {
+		header, err := reader.HeaderAt(context.Background(), sel)
+		if err != nil {
+			fmt.Println("查询区块失败", sel, err)
+			continue
+		}
+		// 用区块哈希固定查询位置，requireCanonical 保证该区块仍在主链上
+		balance, err := reader.BalanceAt(context.Background(), account, blockref.Hash(header.Hash(), true))
+		if err != nil {
+			fmt.Println("查询余额失败", sel, err)
+			continue
+		}
+		fmt.Printf("%s 区块 %d 余额: %s\n", sel, header.Number.Uint64(), balance)
+	}
 }
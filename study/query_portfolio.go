@@ -0,0 +1,75 @@
+// query_portfolio.go - 按 Uniswap 风格的代币列表查询一个或多个账户的 ETH 和代币余额
+// 所有余额通过 Multicall3 在同一区块上批量查询，默认隐藏余额为 0 的资产
+// 用法：
+//
+//	go run study/query_portfolio.go alice bob
+//	go run study/query_portfolio.go -list tokens.json -format csv -zero 0xA... 0xB...
+//	go run study/query_portfolio.go -block finalized alice
+package main
+
+import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/multicall"
+	"eth-client-study/study/portfolio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	listFlag := flag.String("list", "study/tokenlist_sepolia.json", "代币列表 JSON 文件")
+	formatFlag := flag.String("format", portfolio.FormatTable, "输出格式：table、json、csv")
+	zeroFlag := flag.Bool("zero", false, "同时输出余额为 0 的资产")
+	blockFlag := flag.String("block", "", "区块：latest、safe、finalized、区块号或区块哈希，为空时使用 BLOCK_TAG（默认 latest）")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("用法：go run study/query_portfolio.go [参数] <账户地址或名称>...")
+	}
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	book := addressbook.MustOpen(ctx, client)
+	var accounts []common.Address
+	for _, arg := range flag.Args() {
+		accounts = append(accounts, book.MustResolve("账户地址", arg))
+	}
+
+	list, err := portfolio.LoadTokenList(*listFlag)
+	if err != nil {
+		log.Fatal("读取代币列表失败：", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	listed := list.ForChain(chainID.Uint64())
+	sel, err := blockref.ParseOrDefault(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 先把标签解析为具体区块，输出的区块号与查询所用的状态一致
+	head, err := blockref.Header(ctx, client, sel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "代币列表 %s v%s：链 %s 上 %d 个代币，区块 %d\n", list.Name, list.Version, chainID, len(listed), head.Number)
+
+	fetcher := portfolio.NewFetcher(client, multicall.DefaultAddress)
+	fetcher.HideZero = !*zeroFlag
+	result, err := fetcher.Fetch(ctx, blockref.Hash(head.Hash(), false), accounts, listed)
+	if err != nil {
+		log.Fatal("查询余额失败：", err)
+	}
+	if err := portfolio.Write(os.Stdout, result, *formatFlag); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -4,6 +4,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/proof"
+	"fmt"
 	"log"
 
 	"github.com/ethereum/go-ethereum/ethclient" // 提供与以太坊节点交互的客户端
@@ -63,4 +66,32 @@ func main() {
 	//fmt.Println("RECEIPT:", i, "------", string(bytes))
 	//}
 
+	// 校验：根据区块头字段重新计算区块哈希，根据交易和收据重新计算交易树根、收据树根并与区块头比较
+	verified, err := proof.FetchBlock(context.Background(), client.Client(), blockref.Number(5671744))
+	if err != nil {
+		log.Fatal("区块校验失败：", err)
+	}
+	header := verified.Block.Header()
+	fmt.Println("区块哈希校验通过:", header.Hash().Hex())
+	fmt.Println("交易树根校验通过:", header.TxHash.Hex())
+	fmt.Println("收据树根校验通过:", header.ReceiptHash.Hex())
+
+	// 为第一条日志生成包含证明，只要信任区块头中的收据树根即可独立验证，不需要再访问节点
+	for i, receipt := range verified.Receipts {
+		if len(receipt.Logs) == 0 {
+			continue
+		}
+		logProof, err := proof.BuildLogProof(header, verified.Receipts, uint64(i), 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+		jsonProof, _ := json.MarshalIndent(logProof, "", "  ")
+		fmt.Println("日志包含证明:", string(jsonProof))
+		proven, err := proof.VerifyLogProof(header.ReceiptHash, logProof)
+		if err != nil {
+			log.Fatal("日志证明验证失败：", err)
+		}
+		fmt.Printf("日志证明验证通过：交易 %d，合约 %s，与节点返回的日志一致：%v\n", i, proven.Address.Hex(), proof.MatchLog(proven, receipt.Logs[0]))
+		break
+	}
 }
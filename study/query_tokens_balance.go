@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
 	token "eth-client-study/study/erc20"
+	"eth-client-study/study/multicall"
 	"fmt"
 	"log"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -18,41 +21,68 @@ func main() {
 		fmt.Println("连接失败", err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 
-	tokenAddress := common.HexToAddress("0x2f8C29909a2697E4E0449662302aAa1750f2cF98")
-	instance, err := token.NewErc20(tokenAddress, client)
+	tokenAddress := book.MustResolve("代币地址", "mkt")
+	accountAddress := book.MustResolve("账户地址", "bob")
+
+	// 通过 Multicall3 把 BalanceOf、Name、Symbol、Decimals 四次查询合并为一次 eth_call
+	mc, err := multicall.New(client, multicall.DefaultAddress)
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	accountAddress := common.HexToAddress("0xFA73Ee972cB6A7af855846635Ad65427a7009d4e")
-	balance, err := instance.BalanceOf(&bind.CallOpts{}, accountAddress)
+	var (
+		balance  *big.Int
+		name     string
+		symbol   string
+		decimals uint8
+	)
+	calls := []func(instance *token.Erc20Caller) error{
+		func(instance *token.Erc20Caller) (err error) {
+			balance, err = instance.BalanceOf(&bind.CallOpts{}, accountAddress)
+			return err
+		},
+		func(instance *token.Erc20Caller) (err error) {
+			name, err = instance.Name(&bind.CallOpts{})
+			return err
+		},
+		func(instance *token.Erc20Caller) (err error) {
+			symbol, err = instance.Symbol(&bind.CallOpts{})
+			return err
+		},
+		func(instance *token.Erc20Caller) (err error) {
+			decimals, err = instance.Decimals(&bind.CallOpts{})
+			return err
+		},
+	}
+	for _, call := range calls {
+		if _, err := mc.Add(false, func(caller bind.ContractCaller) error {
+			instance, err := token.NewErc20Caller(tokenAddress, caller)
+			if err != nil {
+				return err
+			}
+			return call(instance)
+		}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	// 读取 BLOCK_TAG 对应区块（默认 latest）的状态
+	sel, err := blockref.DefaultFromEnv()
 	if err != nil {
 		log.Fatal(err)
 	}
+	if err := mc.Execute(context.Background(), sel); err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Println("账户余额:", balance.String())
 	//账户余额除以1e18
 	floatBalance := new(big.Float)
 	floatBalance.SetString(balance.String())
 	price := new(big.Float).Quo(floatBalance, big.NewFloat(1e18))
 	fmt.Println("账户余额:", price)
-
-	name, err := instance.Name(&bind.CallOpts{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	fmt.Println("代币名称:", name)
-
-	symbol, err := instance.Symbol(&bind.CallOpts{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	fmt.Println("代币符号:", symbol)
-
-	decimals, err := instance.Decimals(&bind.CallOpts{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	fmt.Println("代币精度:", decimals)
 
 }
@@ -3,14 +3,17 @@ package main
 
 // 导入所需的包
 import (
-	"context"  // 用于控制请求的上下文
-	"fmt"      // 用于格式化输入输出
-	"log"      // 用于记录日志
-	"math/big" // 用于处理大整数
-
-	"github.com/ethereum/go-ethereum/common"     // 以太坊通用工具函数
-	"github.com/ethereum/go-ethereum/core/types" // 以太坊核心类型定义
-	"github.com/ethereum/go-ethereum/ethclient"  // 以太坊客户端库
+	"context"                            // 用于控制请求的上下文
+	"eth-client-study/study/addressbook" // 地址簿
+	"eth-client-study/study/rpcbatch"    // JSON-RPC 批量请求
+	"fmt"                                // 用于格式化输入输出
+	"log"                                // 用于记录日志
+	"math/big"                           // 用于处理大整数
+
+	"github.com/ethereum/go-ethereum/common"         // 以太坊通用工具函数
+	"github.com/ethereum/go-ethereum/common/hexutil" // 十六进制编码
+	"github.com/ethereum/go-ethereum/core/types"     // 以太坊核心类型定义
+	"github.com/ethereum/go-ethereum/ethclient"      // 以太坊客户端库
 )
 
 // 查询交易
@@ -35,6 +38,16 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// 读取地址簿，用于标注交易和日志中的地址
+	book := addressbook.MustOpen(context.Background(), client)
+
+	// 创建调用数据解析器：先使用地址簿中合约的 ABI，再回退到内置的 4 字节签名库
+	// 可通过 SIGNATURE_FILE 环境变量指定自定义签名文件扩展签名库
+	txDecoder, err := book.NewDecoder()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// 创建一个指定区块号5671744的big.Int对象
 	blockNumber := big.NewInt(5671744)
 
@@ -46,8 +59,18 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// 通过 JSON-RPC 批量请求一次性获取区块内所有交易的回执，避免逐笔请求
+	txHashes := make([]common.Hash, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		txHashes[i] = tx.Hash()
+	}
+	receipts, err := rpcbatch.New(client.Client()).Receipts(context.Background(), txHashes)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// 遍历区块中的所有交易
-	for _, tx := range block.Transactions() {
+	for i, tx := range block.Transactions() {
 		// 打印交易哈希值
 		fmt.Println("交易哈希值：", tx.Hash().Hex())
 
@@ -63,38 +86,58 @@ func main() {
 		// 打印交易的nonce值
 		fmt.Println("Nonce值：", tx.Nonce())
 
-		// 打印交易的数据字段（通常是合约调用数据）
-		fmt.Println("交易数据：", tx.Data())
+		// 打印交易的数据字段（通常是合约调用数据），并解析出函数名和参数
+		fmt.Println("交易数据：", hexutil.Encode(tx.Data()))
+		if len(tx.Data()) > 0 {
+			if calls, err := txDecoder.Decode(tx.To(), tx.Data()); err != nil {
+				fmt.Println("调用数据解析失败：", err)
+			} else {
+				// 选择器碰撞时会列出所有能正确解析的候选
+				for _, call := range calls {
+					fmt.Printf("调用函数（%s）：%s\n", call.Source, call)
+				}
+			}
+		}
 
-		// 打印交易接收方地址
-		fmt.Println("接收方地址：", tx.To().Hex())
+		// 打印交易接收方地址，合约创建交易没有接收方
+		if tx.To() != nil {
+			fmt.Println("接收方地址：", book.Annotate(*tx.To()))
+		} else {
+			fmt.Println("接收方地址：（合约创建）")
+		}
 
 		// 打印交易所在的链ID
 		fmt.Println("链ID：", tx.ChainId().Uint64())
 
-		// 使用EIP155签名者从交易中恢复发送方地址
-		if sender, err := types.Sender(types.NewEIP155Signer(chainID), tx); err == nil {
+		// 使用最新签名者从交易中恢复发送方地址（EIP155Signer 无法处理 1559/2930/4844 等类型交易）
+		if sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx); err == nil {
 			// 打印发送方地址
-			fmt.Println("发送方地址：", sender.Hex())
+			fmt.Println("发送方地址：", book.Annotate(sender))
 		} else {
 			// 如果恢复发送方地址失败，则打印错误信息
 			fmt.Println("发送方地址获取失败：", err)
 			log.Fatal(err)
 		}
 
-		// 根据交易哈希获取交易回执
-		receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
-
-		// 检查获取交易回执是否有错误，如果有则记录错误并终止程序
-		if err != nil {
-			log.Fatal(err)
+		// 取出批量请求中该交易对应的回执，单笔失败只打印错误不影响其他交易
+		if receipts[i].Err != nil {
+			fmt.Println("交易回执获取失败：", receipts[i].Err)
+			continue
 		}
+		receipt := receipts[i].Value
 
 		// 打印交易状态（1表示成功，0表示失败）
 		fmt.Println("交易状态：", receipt.Status)
 
-		// 打印交易产生的事件日志
-		fmt.Println("事件日志：", receipt.Logs)
+		// 合约创建交易打印新合约地址
+		if tx.To() == nil {
+			fmt.Println("合约地址：", book.Annotate(receipt.ContractAddress))
+		}
+
+		// 打印交易产生的事件日志，标注日志来自哪个合约
+		for _, vLog := range receipt.Logs {
+			fmt.Printf("事件日志 #%d：%s topics=%d data=%s\n", vLog.Index, book.Annotate(vLog.Address), len(vLog.Topics), hexutil.Encode(vLog.Data))
+		}
 
 		// 只处理第一个交易就跳出循环
 		//break
@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"eth-client-study/study/addressbook"
 	"fmt"
 	"log"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
@@ -18,6 +20,14 @@ func main() {
 	}
 
 	defer client.Close()
+	// 读取地址簿，用于标注交易和日志中的地址
+	book := addressbook.MustOpen(context.Background(), client)
+	// 创建调用数据解析器：先使用地址簿中合约的 ABI，再回退到内置的 4 字节签名库
+	// 可通过 SIGNATURE_FILE 环境变量指定自定义签名文件扩展签名库
+	txDecoder, err := book.NewDecoder()
+	if err != nil {
+		log.Fatal(err)
+	}
 	// 获取当前网络的链ID
 	chainID, err := client.ChainID(context.Background())
 	hashStr := "0x20294a03e8766e9aeab58327fc4112756017c6c28f6f99c7722f4a29075601c5"
@@ -42,19 +52,33 @@ func main() {
 	// 打印交易的nonce值
 	fmt.Println("Nonce值：", tx.Nonce())
 
-	// 打印交易的数据字段（通常是合约调用数据）
-	fmt.Println("交易数据：", tx.Data())
+	// 打印交易的数据字段（通常是合约调用数据），并解析出函数名和参数
+	fmt.Println("交易数据：", hexutil.Encode(tx.Data()))
+	if len(tx.Data()) > 0 {
+		if calls, err := txDecoder.Decode(tx.To(), tx.Data()); err != nil {
+			fmt.Println("调用数据解析失败：", err)
+		} else {
+			// 选择器碰撞时会列出所有能正确解析的候选
+			for _, call := range calls {
+				fmt.Printf("调用函数（%s）：%s\n", call.Source, call)
+			}
+		}
+	}
 
-	// 打印交易接收方地址
-	fmt.Println("接收方地址：", tx.To().Hex())
+	// 打印交易接收方地址，合约创建交易没有接收方
+	if tx.To() != nil {
+		fmt.Println("接收方地址：", book.Annotate(*tx.To()))
+	} else {
+		fmt.Println("接收方地址：（合约创建）")
+	}
 
 	// 打印交易所在的链ID
 	fmt.Println("链ID：", tx.ChainId().Uint64())
 
-	// 使用EIP155签名者从交易中恢复发送方地址
-	if sender, err := types.Sender(types.NewEIP155Signer(chainID), tx); err == nil {
+	// 使用最新签名者从交易中恢复发送方地址（EIP155Signer 无法处理 1559/2930/4844 等类型交易）
+	if sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx); err == nil {
 		// 打印发送方地址
-		fmt.Println("发送方地址：", sender.Hex())
+		fmt.Println("发送方地址：", book.Annotate(sender))
 	} else {
 		// 如果恢复发送方地址失败，则打印错误信息
 		fmt.Println("发送方地址获取失败：", err)
@@ -72,7 +96,14 @@ func main() {
 	// 打印交易状态（1表示成功，0表示失败）
 	fmt.Println("交易状态：", receipt.Status)
 
-	// 打印交易产生的事件日志
-	fmt.Println("事件日志：", receipt.Logs)
+	// 合约创建交易打印新合约地址
+	if tx.To() == nil {
+		fmt.Println("合约地址：", book.Annotate(receipt.ContractAddress))
+	}
+
+	// 打印交易产生的事件日志，标注日志来自哪个合约
+	for _, vLog := range receipt.Logs {
+		fmt.Printf("事件日志 #%d：%s topics=%d data=%s\n", vLog.Index, book.Annotate(vLog.Address), len(vLog.Topics), hexutil.Encode(vLog.Data))
+	}
 
 }
@@ -0,0 +1,316 @@
+package reorg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultWindow 默认保留的最近区块头数量，足以覆盖主网上常见的重组深度
+const DefaultWindow = 128
+
+// ErrReorgTooDeep 重组的共同祖先已经超出窗口范围
+var ErrReorgTooDeep = errors.New("reorg deeper than tracked window")
+
+// HeaderSource 按哈希读取区块头，用于沿新链回溯父区块，*ethclient.Client 满足该接口
+type HeaderSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// HeadSubscriber 可订阅新区块头的数据源
+type HeadSubscriber interface {
+	HeaderSource
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// Event 一次链重组
+// Deep 为 true 时共同祖先超出了窗口：Ancestor 为空，Dropped 是整个旧窗口，Added 是沿新链回溯到的区块，
+// Depth 只是下限，实际的共同祖先不高于 RewindTo()
+type Event struct {
+	Depth    int             // 被回滚的区块数
+	Ancestor *types.Header   // 新旧两条链的共同祖先
+	Dropped  []*types.Header // 被移出主链的区块，按区块号升序
+	Added    []*types.Header // 新加入主链的区块，按区块号升序；链头回退到窗口内已有区块时为空
+	Deep     bool            // 共同祖先超出窗口，跟踪已以新链头重新开始
+}
+
+// RewindTo 本地状态应回滚到的区块：共同祖先；深度重组时取共同祖先的上界，
+// 即旧窗口第一个区块的父区块与回溯到的最早新链区块中较低者
+func (e *Event) RewindTo() uint64 {
+	if e.Ancestor != nil {
+		return e.Ancestor.Number.Uint64()
+	}
+	bound := e.Added[0].Number.Uint64()
+	if n := e.Dropped[0].Number.Uint64(); n > 0 {
+		bound = min(bound, n-1)
+	} else {
+		bound = 0
+	}
+	return bound
+}
+
+// OldHead 重组前的链头
+func (e *Event) OldHead() *types.Header {
+	return e.Dropped[len(e.Dropped)-1]
+}
+
+// NewHead 重组后的链头
+func (e *Event) NewHead() *types.Header {
+	if len(e.Added) == 0 {
+		return e.Ancestor
+	}
+	return e.Added[len(e.Added)-1]
+}
+
+func (e *Event) String() string {
+	if e.Deep {
+		return fmt.Sprintf("deep reorg depth>=%d old head=%d(%s) new head=%d(%s)",
+			e.Depth,
+			e.OldHead().Number.Uint64(), e.OldHead().Hash().TerminalString(),
+			e.NewHead().Number.Uint64(), e.NewHead().Hash().TerminalString())
+	}
+	return fmt.Sprintf("reorg depth=%d ancestor=%d(%s) old head=%d(%s) new head=%d(%s)",
+		e.Depth,
+		e.Ancestor.Number.Uint64(), e.Ancestor.Hash().TerminalString(),
+		e.OldHead().Number.Uint64(), e.OldHead().Hash().TerminalString(),
+		e.NewHead().Number.Uint64(), e.NewHead().Hash().TerminalString())
+}
+
+// Tracker 维护最近若干个主链区块头，检测新链头与当前链是否衔接
+// 新链头的父哈希不是当前链头时，沿新链回溯到窗口内的共同祖先，窗口内之后的区块即为被回滚的区块；
+// 若共同祖先就是当前链头（订阅漏掉了中间的区块），只补齐区块而不产生重组事件
+type Tracker struct {
+	source HeaderSource
+	window int
+
+	mu      sync.RWMutex
+	headers []*types.Header     // 主链区块头，按区块号升序且相邻区块首尾相连
+	index   map[common.Hash]int // 区块哈希 -> headers 中的下标偏移（加上 base）
+	base    int                 // headers[0] 在 index 中的偏移，用于裁剪窗口时不重建索引
+}
+
+// NewTracker 创建跟踪器，window 为保留的区块头数量，<= 0 时使用 DefaultWindow
+func NewTracker(source HeaderSource, window int) *Tracker {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Tracker{source: source, window: window, index: make(map[common.Hash]int)}
+}
+
+// Head 当前链头，尚未收到任何区块时返回 nil
+func (t *Tracker) Head() *types.Header {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.headers) == 0 {
+		return nil
+	}
+	return t.headers[len(t.headers)-1]
+}
+
+// IsCanonical 区块是否在窗口内的主链上
+func (t *Tracker) IsCanonical(hash common.Hash) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.index[hash]
+	return ok
+}
+
+// Canonical 返回窗口内主链上指定高度的区块头
+func (t *Tracker) Canonical(number uint64) (*types.Header, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.headers) == 0 {
+		return nil, false
+	}
+	first := t.headers[0].Number.Uint64()
+	if number < first || number-first >= uint64(len(t.headers)) {
+		return nil, false
+	}
+	return t.headers[number-first], true
+}
+
+// Add 处理一个新链头，发生重组时返回重组事件，否则返回 nil
+// 共同祖先超出窗口时同时返回 Deep 事件和 ErrReorgTooDeep，此时窗口会以新链头重新开始
+func (t *Tracker) Add(ctx context.Context, header *types.Header) (*Event, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.headers) == 0 {
+		t.reset(header)
+		return nil, nil
+	}
+	hash := header.Hash()
+	head := t.headers[len(t.headers)-1]
+	if hash == head.Hash() {
+		return nil, nil
+	}
+	if header.ParentHash == head.Hash() {
+		t.push(header)
+		return nil, nil
+	}
+
+	// 新链头已在窗口内：链头回退到了较早的区块
+	if i, ok := t.position(hash); ok {
+		event := &Event{Ancestor: header, Dropped: cloneHeaders(t.headers[i+1:])}
+		event.Depth = len(event.Dropped)
+		t.truncate(i + 1)
+		return event, nil
+	}
+
+	// 沿新链回溯，直到父区块出现在窗口中
+	first := t.headers[0].Number.Uint64()
+	added := []*types.Header{header}
+	for cur := header; ; {
+		if _, ok := t.position(cur.ParentHash); ok {
+			break
+		}
+		if cur.Number.Uint64() <= first {
+			reverseHeaders(added)
+			event := &Event{Dropped: cloneHeaders(t.headers), Added: added, Deep: true}
+			event.Depth = len(event.Dropped)
+			t.reset(header)
+			return event, fmt.Errorf("%w: no common ancestor at or above block %d", ErrReorgTooDeep, first)
+		}
+		parent, err := t.source.HeaderByHash(ctx, cur.ParentHash)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", cur.ParentHash.Hex(), err)
+		}
+		added = append(added, parent)
+		cur = parent
+	}
+	reverseHeaders(added)
+
+	i, _ := t.position(added[0].ParentHash)
+	var event *Event
+	if i < len(t.headers)-1 {
+		event = &Event{Ancestor: t.headers[i], Dropped: cloneHeaders(t.headers[i+1:])}
+		event.Depth = len(event.Dropped)
+		event.Added = added
+	}
+	t.truncate(i + 1)
+	for _, h := range added {
+		t.push(h)
+	}
+	return event, nil
+}
+
+// Watch 订阅新区块头并把重组事件（包括 Deep 事件）发送到 events，直到 ctx 取消或订阅出错
+// onHead 不为 nil 时，每个新链头在处理完成后都会回调一次
+func (t *Tracker) Watch(ctx context.Context, client HeadSubscriber, events chan<- *Event, onHead func(*types.Header)) error {
+	heads := make(chan *types.Header, 16)
+	sub, err := client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case header := <-heads:
+			event, err := t.Add(ctx, header)
+			if err != nil && !errors.Is(err, ErrReorgTooDeep) {
+				return err
+			}
+			if event != nil {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			if onHead != nil {
+				onHead(header)
+			}
+		}
+	}
+}
+
+func (t *Tracker) position(hash common.Hash) (int, bool) {
+	i, ok := t.index[hash]
+	return i - t.base, ok
+}
+
+func (t *Tracker) reset(header *types.Header) {
+	t.headers = nil
+	t.index = make(map[common.Hash]int)
+	t.base = 0
+	t.push(header)
+}
+
+// push 追加区块头并裁剪超出窗口的旧区块
+func (t *Tracker) push(header *types.Header) {
+	t.index[header.Hash()] = t.base + len(t.headers)
+	t.headers = append(t.headers, header)
+	if over := len(t.headers) - t.window; over > 0 {
+		for _, h := range t.headers[:over] {
+			delete(t.index, h.Hash())
+		}
+		t.headers = append(t.headers[:0:0], t.headers[over:]...)
+		t.base += over
+	}
+}
+
+// truncate 只保留前 n 个区块头
+func (t *Tracker) truncate(n int) {
+	for _, h := range t.headers[n:] {
+		delete(t.index, h.Hash())
+	}
+	t.headers = t.headers[:n:n]
+}
+
+func cloneHeaders(headers []*types.Header) []*types.Header {
+	return append([]*types.Header(nil), headers...)
+}
+
+func reverseHeaders(headers []*types.Header) {
+	for i, j := 0, len(headers)-1; i < j; i, j = i+1, j-1 {
+		headers[i], headers[j] = headers[j], headers[i]
+	}
+}
+
+// Resyncer 可回滚并重新同步的本地索引，holders.Indexer、storekv.Mirror 和 nft.Indexer 都满足
+type Resyncer interface {
+	Rewind(block uint64)
+	Sync(ctx context.Context, from, to uint64) error
+}
+
+// Follow 订阅新区块头，发生重组时把 indexers 回滚到 RewindTo() 并从下一个区块重新同步到新链头，
+// 直到 ctx 取消、订阅出错或重新同步失败；onEvent 不为 nil 时在处理每个重组事件之前回调
+// 索引自身的 Watch 只能处理订阅推送的 Removed 日志，订阅断开期间或超出窗口的重组需要这里兜底
+func (t *Tracker) Follow(ctx context.Context, client HeadSubscriber, onEvent func(*Event), indexers ...Resyncer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := make(chan *Event, 16)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- t.Watch(ctx, client, events, nil)
+	}()
+	for {
+		select {
+		case err := <-errc:
+			return err
+		case event := <-events:
+			if onEvent != nil {
+				onEvent(event)
+			}
+			from, to := event.RewindTo()+1, event.NewHead().Number.Uint64()
+			for _, x := range indexers {
+				x.Rewind(from - 1)
+				if from > to {
+					continue
+				}
+				if err := x.Sync(ctx, from, to); err != nil {
+					return fmt.Errorf("resync [%d, %d]: %w", from, to, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultChunkSize 回放历史事件时每次 eth_getLogs 查询的区块数，多数服务商限制在 10000 以内
+const DefaultChunkSize = 5000
+
+// FilterLogs 把 [from, to] 按 chunk 个区块一段查询 query 匹配的日志（query 的 FromBlock/ToBlock 被忽略），
+// 每段查询完调用一次 fn，end 为该段的最后一个区块；chunk 为 0 时使用 DefaultChunkSize
+func FilterLogs(ctx context.Context, backend bind.ContractFilterer, query ethereum.FilterQuery, from, to, chunk uint64, fn func(logs []types.Log, end uint64) error) error {
+	if chunk == 0 {
+		chunk = DefaultChunkSize
+	}
+	for start := from; start <= to; start += chunk {
+		end := min(start+chunk-1, to)
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+		logs, err := backend.FilterLogs(ctx, query)
+		if err != nil {
+			return fmt.Errorf("filter logs [%d, %d]: %w", start, end, err)
+		}
+		if err := fn(logs, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Position 日志在链上的位置，同一位置的日志只计入一次
+type Position struct {
+	Block uint64
+	Index uint
+}
+
+// PositionOf 日志的位置
+func PositionOf(log types.Log) Position {
+	return Position{Block: log.BlockNumber, Index: log.Index}
+}
+
+// Handler 索引对事件的处理，Apply 和 Revert 在 Replayer 内部加锁后调用，不能再调用 Replayer 的方法
+type Handler[T any] struct {
+	Parse  func(log types.Log) ([]T, error) // 把一条日志解析为事件，不关心的日志返回空
+	Apply  func(event T)                    // 计入一个事件
+	Revert func(event T)                    // 撤销一个之前计入的事件
+}
+
+// Replayer 通过回放合约事件日志在本地重建状态，holders.Indexer、nft.Indexer 和 storekv.Mirror 只提供事件的解析与计入/撤销
+// 先 Sync 回放历史，再 Watch 订阅新日志保持同步；已计入的事件按日志位置记录，
+// 链重组时被移除的日志由 Revert 撤销，深度重组后 Rewind 到共同祖先再重新 Sync
+type Replayer[T any] struct {
+	backend bind.ContractFilterer
+	query   ethereum.FilterQuery
+	handler Handler[T]
+
+	mu     sync.Mutex
+	seen   map[Position][]T // 已计入的事件，用于去重和撤销
+	synced uint64           // 已完整回放到的区块
+
+	ChunkSize uint64
+}
+
+// New 创建回放器，query 只需设置 Addresses 和 Topics
+func New[T any](backend bind.ContractFilterer, query ethereum.FilterQuery, handler Handler[T]) *Replayer[T] {
+	return &Replayer[T]{
+		backend:   backend,
+		query:     query,
+		handler:   handler,
+		seen:      make(map[Position][]T),
+		ChunkSize: DefaultChunkSize,
+	}
+}
+
+// Synced 已完整回放到的区块
+func (r *Replayer[T]) Synced() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.synced
+}
+
+// Advance 把 Synced 推进到 block（不会后退），用于从快照恢复
+func (r *Replayer[T]) Advance(block uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.synced = max(r.synced, block)
+}
+
+// Sync 分段回放 [from, to] 内的日志，from 一般为合约部署区块（见 blocktime.DeploymentBlock）
+func (r *Replayer[T]) Sync(ctx context.Context, from, to uint64) error {
+	return FilterLogs(ctx, r.backend, r.query, from, to, r.ChunkSize, func(logs []types.Log, end uint64) error {
+		for _, log := range logs {
+			if err := r.ApplyLog(log); err != nil {
+				return err
+			}
+		}
+		r.Advance(end)
+		return nil
+	})
+}
+
+// Watch 订阅新的日志，同时补齐 Synced 之后到当前链头之间的历史，直到 ctx 取消或订阅出错
+// 先订阅再补历史，两者重叠部分的日志按位置去重，不会漏掉补历史期间产生的日志
+func (r *Replayer[T]) Watch(ctx context.Context, head func(context.Context) (uint64, error)) error {
+	logs := make(chan types.Log, 64)
+	sub, err := r.backend.SubscribeFilterLogs(ctx, r.query, logs)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	latest, err := head(ctx)
+	if err != nil {
+		return err
+	}
+	if from := r.Synced() + 1; from <= latest {
+		if err := r.Sync(ctx, from, latest); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case log := <-logs:
+			if log.Removed {
+				// 链重组：该日志所在区块已不在主链上
+				r.Revert(PositionOf(log))
+				continue
+			}
+			if err := r.ApplyLog(log); err != nil {
+				return err
+			}
+			// 同一区块可能还有后续日志，因此只标记到上一个区块
+			if log.BlockNumber > 0 {
+				r.Advance(log.BlockNumber - 1)
+			}
+		}
+	}
+}
+
+// ApplyLog 解析并计入一条日志中的全部事件
+func (r *Replayer[T]) ApplyLog(log types.Log) error {
+	events, err := r.handler.Parse(log)
+	if err != nil {
+		return fmt.Errorf("block %d log %d: %w", log.BlockNumber, log.Index, err)
+	}
+	r.Apply(PositionOf(log), events...)
+	return nil
+}
+
+// Apply 计入 pos 处日志中的事件，该位置已经计入过时忽略
+func (r *Replayer[T]) Apply(pos Position, events ...T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.seen[pos]; ok {
+		return
+	}
+	r.seen[pos] = events
+	for _, event := range events {
+		r.handler.Apply(event)
+	}
+}
+
+// Revert 撤销 pos 处日志中的事件（对应 Removed 为 true 的日志），Synced 退回到该区块之前
+func (r *Replayer[T]) Revert(pos Position) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revert(pos)
+	if pos.Block > 0 && r.synced >= pos.Block {
+		r.synced = pos.Block - 1
+	}
+}
+
+// Rewind 撤销 block 之后的所有事件，用于检测到深度重组后从 block+1 重新 Sync
+func (r *Replayer[T]) Rewind(block uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var positions []Position
+	for pos := range r.seen {
+		if pos.Block > block {
+			positions = append(positions, pos)
+		}
+	}
+	// 从最新的日志开始撤销
+	sort.Slice(positions, func(i, j int) bool {
+		a, b := positions[i], positions[j]
+		return a.Block > b.Block || a.Block == b.Block && a.Index > b.Index
+	})
+	for _, pos := range positions {
+		r.revert(pos)
+	}
+	r.synced = min(r.synced, block)
+}
+
+func (r *Replayer[T]) revert(pos Position) {
+	events, ok := r.seen[pos]
+	if !ok {
+		return
+	}
+	delete(r.seen, pos)
+	for i := len(events) - 1; i >= 0; i-- {
+		r.handler.Revert(events[i])
+	}
+}
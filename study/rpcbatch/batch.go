@@ -0,0 +1,191 @@
+package rpcbatch
+
+import (
+	"context"
+	"encoding/json"
+	"eth-client-study/study/blockref"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultMaxBatchSize 单个 HTTP 批量请求默认包含的最大调用数
+// 多数节点服务商限制在 100～1000 之间
+const DefaultMaxBatchSize = 100
+
+// Result 批量请求中单个元素的结果，Err 不为空时 Value 无效
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// BatchCaller 发送 JSON-RPC 批量请求的接口，*rpc.Client 满足
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// Batcher 基于 BatchCallContext 的批量请求封装
+// 把大量同类查询合并为少数几个 HTTP 请求，并逐个元素返回错误
+type Batcher struct {
+	client BatchCaller
+
+	MaxBatchSize int // 每个批量请求的最大调用数
+}
+
+// New 创建批量请求器，可通过 ethclient.Client.Client() 获得 rpc.Client
+func New(client BatchCaller) *Batcher {
+	return &Batcher{client: client, MaxBatchSize: DefaultMaxBatchSize}
+}
+
+// Receipts 批量获取交易回执
+func (b *Batcher) Receipts(ctx context.Context, hashes []common.Hash) ([]Result[*types.Receipt], error) {
+	args := make([][]interface{}, len(hashes))
+	for i, hash := range hashes {
+		args[i] = []interface{}{hash}
+	}
+	return call(ctx, b, "eth_getTransactionReceipt", args, func(raw json.RawMessage) (*types.Receipt, error) {
+		receipt := new(types.Receipt)
+		return receipt, json.Unmarshal(raw, receipt)
+	})
+}
+
+// Transactions 批量按哈希获取交易
+func (b *Batcher) Transactions(ctx context.Context, hashes []common.Hash) ([]Result[*types.Transaction], error) {
+	args := make([][]interface{}, len(hashes))
+	for i, hash := range hashes {
+		args[i] = []interface{}{hash}
+	}
+	return call(ctx, b, "eth_getTransactionByHash", args, func(raw json.RawMessage) (*types.Transaction, error) {
+		tx := new(types.Transaction)
+		return tx, json.Unmarshal(raw, tx)
+	})
+}
+
+// Headers 批量获取区块头，选择器可以是区块号、标签或区块哈希（零值为 latest）
+// 按哈希且 requireCanonical 的元素会再批量按区块号核对，已不在主链上的记为 blockref.ErrNotCanonical
+func (b *Batcher) Headers(ctx context.Context, sels []blockref.Selector) ([]Result[*types.Header], error) {
+	methods := make([]string, len(sels))
+	args := make([][]interface{}, len(sels))
+	for i, sel := range sels {
+		if hash, ok := sel.BlockHash(); ok {
+			methods[i], args[i] = "eth_getBlockByHash", []interface{}{hash, false}
+		} else {
+			methods[i], args[i] = "eth_getBlockByNumber", []interface{}{sel, false}
+		}
+	}
+	results, err := callEach(ctx, b, methods, args, decodeHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []int
+	for i, sel := range sels {
+		if _, ok := sel.BlockHash(); ok && sel.RequireCanonical() && results[i].Err == nil {
+			checks = append(checks, i)
+		}
+	}
+	if len(checks) == 0 {
+		return results, nil
+	}
+	methods, args = methods[:0], args[:0]
+	for _, i := range checks {
+		methods = append(methods, "eth_getBlockByNumber")
+		args = append(args, []interface{}{(*hexutil.Big)(results[i].Value.Number), false})
+	}
+	canonical, err := callEach(ctx, b, methods, args, decodeHeader)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range checks {
+		header := results[i].Value
+		switch {
+		case canonical[j].Err != nil:
+			results[i] = Result[*types.Header]{Err: canonical[j].Err}
+		case canonical[j].Value.Hash() != header.Hash():
+			results[i] = Result[*types.Header]{Err: fmt.Errorf("%w: %s at height %d", blockref.ErrNotCanonical, header.Hash().Hex(), header.Number.Uint64())}
+		}
+	}
+	return results, nil
+}
+
+func decodeHeader(raw json.RawMessage) (*types.Header, error) {
+	header := new(types.Header)
+	return header, json.Unmarshal(raw, header)
+}
+
+// Balances 批量获取多个账户在 sel 对应区块（零值为 latest）的 ETH 余额
+func (b *Batcher) Balances(ctx context.Context, accounts []common.Address, sel blockref.Selector) ([]Result[*big.Int], error) {
+	args := make([][]interface{}, len(accounts))
+	for i, account := range accounts {
+		args[i] = []interface{}{account, sel}
+	}
+	return call(ctx, b, "eth_getBalance", args, func(raw json.RawMessage) (*big.Int, error) {
+		var balance hexutil.Big
+		if err := json.Unmarshal(raw, &balance); err != nil {
+			return nil, err
+		}
+		return balance.ToInt(), nil
+	})
+}
+
+// StorageAt 批量读取同一合约在 sel 对应区块（零值为 latest）的多个存储槽
+func (b *Batcher) StorageAt(ctx context.Context, account common.Address, slots []common.Hash, sel blockref.Selector) ([]Result[common.Hash], error) {
+	args := make([][]interface{}, len(slots))
+	for i, slot := range slots {
+		args[i] = []interface{}{account, slot, sel}
+	}
+	return call(ctx, b, "eth_getStorageAt", args, func(raw json.RawMessage) (common.Hash, error) {
+		var value hexutil.Bytes
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return common.Hash{}, err
+		}
+		return common.BytesToHash(value), nil
+	})
+}
+
+// call 把同一方法的多组参数按 MaxBatchSize 分批发送
+func call[T any](ctx context.Context, b *Batcher, method string, args [][]interface{}, decode func(json.RawMessage) (T, error)) ([]Result[T], error) {
+	methods := make([]string, len(args))
+	for i := range methods {
+		methods[i] = method
+	}
+	return callEach(ctx, b, methods, args, decode)
+}
+
+// callEach 按 MaxBatchSize 分批发送，第 i 个调用的方法为 methods[i]、参数为 args[i]
+// 整个 HTTP 请求失败时返回 error；单个元素失败（或结果为 null）记录在对应 Result.Err 中
+func callEach[T any](ctx context.Context, b *Batcher, methods []string, args [][]interface{}, decode func(json.RawMessage) (T, error)) ([]Result[T], error) {
+	size := b.MaxBatchSize
+	if size <= 0 {
+		size = DefaultMaxBatchSize
+	}
+	results := make([]Result[T], len(args))
+	for start := 0; start < len(args); start += size {
+		end := min(start+size, len(args))
+		raws := make([]json.RawMessage, end-start)
+		elems := make([]rpc.BatchElem, end-start)
+		for i := range elems {
+			elems[i] = rpc.BatchElem{Method: methods[start+i], Args: args[start+i], Result: &raws[i]}
+		}
+		if err := b.client.BatchCallContext(ctx, elems); err != nil {
+			return nil, fmt.Errorf("%s batch [%d, %d): %w", methods[start], start, end, err)
+		}
+		for i, elem := range elems {
+			result := &results[start+i]
+			switch {
+			case elem.Error != nil:
+				result.Err = elem.Error
+			case len(raws[i]) == 0 || string(raws[i]) == "null":
+				result.Err = ethereum.NotFound
+			default:
+				result.Value, result.Err = decode(raws[i])
+			}
+		}
+	}
+	return results, nil
+}
@@ -3,7 +3,8 @@ package main
 import (
 	"context"
 	"crypto/ecdsa"
-	"eth-client-study/study/store"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/storekv"
 	"eth-client-study/utils"
 	"fmt"
 	"log"
@@ -31,6 +32,7 @@ func runSetItemsByAbi() {
 		log.Fatal(err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 	privateKey, err := crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1"))
 	if err != nil {
 		log.Fatal(err)
@@ -60,14 +62,18 @@ func runSetItemsByAbi() {
 	}
 	//函数名称
 	methodName := "setItem"
-	var key [32]byte
-	var value [32]byte
-
-	copy(key[:], "demo_save_key_use_abi")
-	copy(value[:], "demo_save_value_use_abi_11111")
+	// 字符串按 UTF-8 编码为 bytes32，超过 32 字节时报错而不是截断
+	key, err := storekv.String.Encode("demo_save_key_use_abi")
+	if err != nil {
+		log.Fatal(err)
+	}
+	value, err := storekv.String.Encode("demo_save_value_use_abi_11111")
+	if err != nil {
+		log.Fatal(err)
+	}
 	input, err := contractABI.Pack(methodName, key, value)
 
-	tx := types.NewTransaction(nonce, common.HexToAddress("0x183AdfEe585d04Db1Ab151840D6399009beC2bC4"), big.NewInt(0), 300000, gasPrice, input)
+	tx := types.NewTransaction(nonce, book.MustResolve("合约地址", "store"), big.NewInt(0), 300000, gasPrice, input)
 	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(11155111)), privateKey)
 	if err != nil {
 		log.Fatal("交易签名失败：", err)
@@ -89,7 +95,7 @@ func runSetItemsByAbi() {
 		log.Fatal(err)
 	}
 
-	to := common.HexToAddress("0x183AdfEe585d04Db1Ab151840D6399009beC2bC4")
+	to := book.MustResolve("合约地址", "store")
 	callMsg := ethereum.CallMsg{
 		To:   &to,
 		Data: callInput,
@@ -117,6 +123,7 @@ func runSetItemsNoUseAbi() {
 		log.Fatal(err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 	privateKey, err := crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1"))
 	if err != nil {
 		log.Fatal(err)
@@ -141,10 +148,14 @@ func runSetItemsNoUseAbi() {
 	methodSignature := []byte("setItem(bytes32,bytes32)")
 	methodSelector := crypto.Keccak256(methodSignature)[:4]
 
-	var key [32]byte
-	var value [32]byte
-	copy(key[:], []byte("demo_save_key_no_use_abi"))
-	copy(value[:], []byte("demo_save_value_no_use_abi_11111"))
+	key, err := storekv.String.Encode("demo_save_key_no_use_abi")
+	if err != nil {
+		log.Fatal(err)
+	}
+	value, err := storekv.String.Encode("demo_save_value_no_use_abi_11111")
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// 组合调用数据
 	var input []byte
@@ -152,7 +163,7 @@ func runSetItemsNoUseAbi() {
 	input = append(input, key[:]...)
 	input = append(input, value[:]...)
 
-	tx := types.NewTransaction(nonce, common.HexToAddress("0x183AdfEe585d04Db1Ab151840D6399009beC2bC4"), big.NewInt(0), 300000, gasPrice, input)
+	tx := types.NewTransaction(nonce, book.MustResolve("合约地址", "store"), big.NewInt(0), 300000, gasPrice, input)
 	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(11155111)), privateKey)
 	if err != nil {
 		log.Fatal("交易签名失败：", err)
@@ -175,7 +186,7 @@ func runSetItemsNoUseAbi() {
 	callInput = append(callInput, itemsSelector...)
 	callInput = append(callInput, key[:]...)
 
-	to := common.HexToAddress("0x183AdfEe585d04Db1Ab151840D6399009beC2bC4")
+	to := book.MustResolve("合约地址", "store")
 	callMsg := ethereum.CallMsg{
 		To:   &to,
 		Data: callInput,
@@ -212,13 +223,15 @@ func runSetItems() {
 		log.Fatal(err)
 	}
 	defer client.Close()
+	book := addressbook.MustOpen(context.Background(), client)
 
-	contractAddr := "0x183AdfEe585d04Db1Ab151840D6399009beC2bC4"
-	storeContract, err := store.NewStore(common.HexToAddress(contractAddr), client)
+	contractAddr := "store" // 地址簿中的名称，也可以直接写地址
+	// storekv.Client 统一负责 key/value 与 bytes32 之间的编码
+	storeClient, err := storekv.NewClient(book.MustResolve("合约地址", contractAddr), client)
 	if err != nil {
 		log.Fatal(err)
 	}
-	version, err := storeContract.Version(&bind.CallOpts{})
+	version, err := storeClient.Version(&bind.CallOpts{})
 	if err != nil {
 
 		log.Fatal(err)
@@ -230,41 +243,30 @@ func runSetItems() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	var key [32]byte
-	var value [32]byte
-	copy(key[:], "demo_save_key")
-	copy(value[:], "demo_save_value11111")
 	opt, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(11155111))
 	if err != nil {
 		log.Fatal(err)
 	}
-	tx, err := storeContract.SetItem(opt, key, value)
+	tx, err := storeClient.Set(opt, "demo_save_key", "demo_save_value11111")
 	if err != nil {
 		log.Fatal(err)
 	}
 	log.Println("tx:", tx.Hash().Hex())
+	log.Println("等待交易确认...")
 	receipt, err := bind.WaitMined(context.Background(), client, tx)
 	if err != nil {
 		log.Fatal(err)
 	}
-	log.Println("receipt:", receipt.Status)
-	log.Println("等待交易确认...")
-	for receipt.Status != 1 {
-		receipt, err = client.TransactionReceipt(context.Background(), tx.Hash())
-		if err != nil {
-			log.Fatal(err)
-		}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		log.Fatal("交易执行失败")
 	}
 	log.Println("交易已确认")
-	log.Println("等待交易确认结束")
 
 	//读取items
-	items, err := storeContract.Items(&bind.CallOpts{}, key)
+	value, found, err := storeClient.Get(&bind.CallOpts{}, "demo_save_key")
 	if err != nil {
 		log.Fatal(err)
 	}
-	log.Println("items:", items)
+	log.Println("items:", value, "已设置:", found)
 	log.Println("交易结束")
-	//字节数组转字符串
-	log.Println("items:", string(items[:]))
 }
@@ -0,0 +1,110 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"eth-client-study/utils"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrBadSignature 签名格式错误或无法恢复出公钥
+var ErrBadSignature = errors.New("invalid signature")
+
+// Signer 能对 32 字节摘要签名的账户，私钥可以在本地，也可以在硬件钱包或远程签名服务中
+// SignHash 返回 65 字节的 [R || S || V]，V 为 0 或 1（与 crypto.Sign 一致）
+type Signer interface {
+	Address() common.Address
+	SignHash(hash common.Hash) ([]byte, error)
+}
+
+// KeySigner 用内存中的私钥签名
+type KeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewKeySigner 创建本地私钥签名者
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// FromEnv 从 .env 中读取十六进制私钥（如 PRIVATE_KEY1）创建签名者
+func FromEnv(name string) (*KeySigner, error) {
+	key, err := crypto.HexToECDSA(utils.GetEnv(name))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return NewKeySigner(key), nil
+}
+
+// Address 签名账户地址
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash 对摘要签名
+func (s *KeySigner) SignHash(hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), s.key)
+}
+
+// TransactOpts 返回用该私钥签名交易的参数
+func (s *KeySigner) TransactOpts(chainID *big.Int) (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(s.key, chainID)
+}
+
+// TypedDataHash 按 EIP-712 计算 keccak256("\x19\x01" || domainSeparator || hashStruct(message))
+func TypedDataHash(data apitypes.TypedData) (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// SignTypedData 对 EIP-712 结构化数据签名，返回的签名 V 为 27 或 28，与 eth_signTypedData_v4 和合约中的 ecrecover 一致
+func SignTypedData(s Signer, data apitypes.TypedData) ([]byte, error) {
+	hash, err := TypedDataHash(data)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.SignHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrBadSignature, len(sig))
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// RecoverTypedData 从 EIP-712 签名中恢复签名者地址，V 可以是 0/1 或 27/28
+func RecoverTypedData(data apitypes.TypedData, sig []byte) (common.Address, error) {
+	hash, err := TypedDataHash(data)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return RecoverHash(hash, sig)
+}
+
+// RecoverHash 从摘要签名中恢复签名者地址，V 可以是 0/1 或 27/28
+func RecoverHash(hash common.Hash, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("%w: %d bytes", ErrBadSignature, len(sig))
+	}
+	normalized := common.CopyBytes(sig)
+	if normalized[crypto.RecoveryIDOffset] >= 27 {
+		normalized[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), normalized)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,221 @@
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"eth-client-study/study/create2"
+	"eth-client-study/study/govtoken"
+	"eth-client-study/study/multicall3"
+	"eth-client-study/study/store"
+	"eth-client-study/task01/counter"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	ethsim "github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// 每个测试账户的初始余额：1000 ETH
+var defaultBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+
+// Harness 基于 go-ethereum 模拟后端的离线测试链
+// 预先为若干账户注入余额，并提供部署本项目合约的快捷方法
+type Harness struct {
+	Backend  *ethsim.Backend
+	Client   ethsim.Client
+	ChainID  *big.Int
+	Keys     []*ecdsa.PrivateKey
+	Accounts []common.Address
+}
+
+// NewHarness 创建一条包含 accounts 个已注资账户的模拟链
+func NewHarness(accounts int) (*Harness, error) {
+	if accounts <= 0 {
+		return nil, errors.New("at least one account is required")
+	}
+	h := &Harness{}
+	alloc := make(types.GenesisAlloc)
+	for i := 0; i < accounts; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		alloc[address] = types.Account{Balance: new(big.Int).Set(defaultBalance)}
+		h.Keys = append(h.Keys, key)
+		h.Accounts = append(h.Accounts, address)
+	}
+	// 允许不带链 ID 的交易，确定性部署代理只能用这样的预签名交易部署（见 create2.EnsureFactory）
+	h.Backend = ethsim.NewBackend(alloc, func(nodeConf *node.Config, _ *ethconfig.Config) {
+		nodeConf.AllowUnprotectedTxs = true
+	})
+	h.Client = h.Backend.Client()
+
+	chainID, err := h.Client.ChainID(context.Background())
+	if err != nil {
+		h.Backend.Close()
+		return nil, err
+	}
+	h.ChainID = chainID
+	return h, nil
+}
+
+// Close 关闭模拟链
+func (h *Harness) Close() error {
+	return h.Backend.Close()
+}
+
+// Commit 打包当前交易池中的交易并生成新区块
+func (h *Harness) Commit() common.Hash {
+	return h.Backend.Commit()
+}
+
+// TransactOpts 返回第 i 个账户的交易签名参数
+func (h *Harness) TransactOpts(i int) (*bind.TransactOpts, error) {
+	if i < 0 || i >= len(h.Keys) {
+		return nil, fmt.Errorf("account index %d out of range", i)
+	}
+	return bind.NewKeyedTransactorWithChainID(h.Keys[i], h.ChainID)
+}
+
+// WaitMined 出块并返回交易回执，交易执行失败时返回错误
+func (h *Harness) WaitMined(tx *types.Transaction) (*types.Receipt, error) {
+	h.Commit()
+	receipt, err := h.Client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// DeployStore 使用第一个账户部署 Store 合约
+func (h *Harness) DeployStore(version string) (common.Address, *store.Store, error) {
+	opts, err := h.TransactOpts(0)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	address, tx, instance, err := store.DeployStore(opts, h.Client, version)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if _, err := h.WaitMined(tx); err != nil {
+		return common.Address{}, nil, err
+	}
+	return address, instance, nil
+}
+
+// DeployCounter 使用第一个账户部署 Counter 合约
+func (h *Harness) DeployCounter() (common.Address, *counter.Counter, error) {
+	opts, err := h.TransactOpts(0)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	address, tx, instance, err := counter.DeployCounter(opts, h.Client)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if _, err := h.WaitMined(tx); err != nil {
+		return common.Address{}, nil, err
+	}
+	return address, instance, nil
+}
+
+// DeployMulticall3 使用第一个账户部署 Multicall3 合约
+// 模拟链上的地址与主网统一地址不同，使用时需传入返回的地址
+func (h *Harness) DeployMulticall3() (common.Address, *multicall3.Multicall3, error) {
+	opts, err := h.TransactOpts(0)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	address, tx, instance, err := multicall3.DeployMulticall3(opts, h.Client)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if _, err := h.WaitMined(tx); err != nil {
+		return common.Address{}, nil, err
+	}
+	return address, instance, nil
+}
+
+// DeployPermitToken 使用第一个账户部署支持 EIP-2612 permit 的 ERC20 测试代币（Optimism 的 GovernanceToken，名称 Optimism，符号 OP，源码见 study/GovernanceToken.sol）
+// 部署后总量为 0，由第一个账户调用 Mint 铸造
+func (h *Harness) DeployPermitToken() (common.Address, *govtoken.GovernanceToken, error) {
+	opts, err := h.TransactOpts(0)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	address, tx, instance, err := govtoken.DeployGovernanceToken(opts, h.Client)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if _, err := h.WaitMined(tx); err != nil {
+		return common.Address{}, nil, err
+	}
+	return address, instance, nil
+}
+
+// Create2Deployer 返回通过确定性部署代理部署合约的部署器，等待交易时自动出块
+func (h *Harness) Create2Deployer() *create2.Deployer {
+	d := create2.NewDeployer(h.Client)
+	d.Wait = func(_ context.Context, tx *types.Transaction) (*types.Receipt, error) {
+		return h.WaitMined(tx)
+	}
+	return d
+}
+
+// deployCreate2 需要时先部署代理，再使用第一个账户通过代理部署 initCode
+func (h *Harness) deployCreate2(salt common.Hash, initCode []byte) (*create2.Deployment, error) {
+	opts, err := h.TransactOpts(0)
+	if err != nil {
+		return nil, err
+	}
+	deployer := h.Create2Deployer()
+	if _, err := deployer.EnsureFactory(opts); err != nil {
+		return nil, err
+	}
+	return deployer.Deploy(opts, salt, initCode)
+}
+
+// DeployStoreCreate2 通过确定性部署代理部署 Store 合约，地址与在真实网络上使用相同 salt 和 version 部署时一致
+func (h *Harness) DeployStoreCreate2(salt common.Hash, version string) (*create2.Deployment, *store.Store, error) {
+	initCode, err := create2.InitCode(store.StoreMetaData, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	deployment, err := h.deployCreate2(salt, initCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	instance, err := store.NewStore(deployment.Address, h.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deployment, instance, nil
+}
+
+// DeployCounterCreate2 通过确定性部署代理部署 Counter 合约
+func (h *Harness) DeployCounterCreate2(salt common.Hash) (*create2.Deployment, *counter.Counter, error) {
+	initCode, err := create2.InitCode(counter.CounterMetaData)
+	if err != nil {
+		return nil, nil, err
+	}
+	deployment, err := h.deployCreate2(salt, initCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	instance, err := counter.NewCounter(deployment.Address, h.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deployment, instance, nil
+}
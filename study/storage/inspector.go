@@ -0,0 +1,312 @@
+package storage
+
+import (
+	"context"
+	"eth-client-study/study/blockref"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StorageReader 在指定区块读取存储槽，*blockref.Reader 满足该接口
+type StorageReader interface {
+	StorageAt(ctx context.Context, account common.Address, slot common.Hash, sel blockref.Selector) ([]byte, error)
+}
+
+// Value 解析路径得到的存储值
+type Value struct {
+	Path    string
+	Type    string      // 类型标签，例如 uint256、string、mapping(bytes32 => bytes32)
+	Slot    common.Hash // 值所在的槽（长 string/bytes 为存放长度的槽）
+	Offset  int         // 槽内字节偏移
+	Decoded interface{} // 解码后的值；映射为 nil，动态数组和定长数组为长度，结构体为按声明顺序排列的成员
+}
+
+// Inspector 根据存储布局直接读取合约存储，不依赖合约是否提供 getter
+type Inspector struct {
+	reader   StorageReader
+	contract common.Address
+	layout   *Layout
+}
+
+// NewInspector 创建存储查看器
+func NewInspector(reader StorageReader, contract common.Address, layout *Layout) *Inspector {
+	return &Inspector{reader: reader, contract: contract, layout: layout}
+}
+
+// location 路径解析到的位置
+type location struct {
+	typ    *Type
+	slot   common.Hash
+	offset int
+}
+
+// Locate 只计算路径对应的槽位而不读取值，映射和定长数组不需要访问节点；动态数组需要读取长度做越界检查
+// 路径语法：变量名后接任意个 [key] 或 .member，例如 items[0x01]、balances[0xabc…]、users[3].name、matrix[1][2]
+func (i *Inspector) Locate(ctx context.Context, path string, sel blockref.Selector) (*Type, common.Hash, int, error) {
+	loc, err := i.locate(ctx, path, sel)
+	if err != nil {
+		return nil, common.Hash{}, 0, err
+	}
+	return loc.typ, loc.slot, loc.offset, nil
+}
+
+// Read 读取路径对应的值
+func (i *Inspector) Read(ctx context.Context, path string, sel blockref.Selector) (*Value, error) {
+	loc, err := i.locate(ctx, path, sel)
+	if err != nil {
+		return nil, err
+	}
+	return i.read(ctx, path, loc, sel)
+}
+
+// ReadAll 读取所有状态变量，映射无法枚举，只返回其槽位
+func (i *Inspector) ReadAll(ctx context.Context, sel blockref.Selector) ([]*Value, error) {
+	values := make([]*Value, 0, len(i.layout.Storage))
+	for _, v := range i.layout.Storage {
+		value, err := i.Read(ctx, v.Label, sel)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", v.Label, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (i *Inspector) locate(ctx context.Context, path string, sel blockref.Selector) (location, error) {
+	name, steps, err := splitPath(path)
+	if err != nil {
+		return location{}, err
+	}
+	v, ok := i.layout.Variable(name)
+	if !ok {
+		return location{}, fmt.Errorf("no state variable %q", name)
+	}
+	loc, err := i.member(location{}, v)
+	if err != nil {
+		return location{}, err
+	}
+	for _, step := range steps {
+		if loc, err = i.step(ctx, loc, step, sel); err != nil {
+			return location{}, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return loc, nil
+}
+
+// member 计算变量或结构体成员的位置，base 为结构体起始槽（顶层变量为 0）
+func (i *Inspector) member(base location, v Variable) (location, error) {
+	typ, err := i.layout.typeOf(v.Type)
+	if err != nil {
+		return location{}, err
+	}
+	slot, err := v.SlotNumber()
+	if err != nil {
+		return location{}, err
+	}
+	return location{typ: typ, slot: AddSlot(base.slot, slot), offset: v.Offset}, nil
+}
+
+func (i *Inspector) step(ctx context.Context, loc location, step string, sel blockref.Selector) (location, error) {
+	typ := loc.typ
+	if strings.HasPrefix(step, ".") {
+		if len(typ.Members) == 0 {
+			return location{}, fmt.Errorf("%s has no members", typ.Label)
+		}
+		name := step[1:]
+		for _, m := range typ.Members {
+			if m.Label == name {
+				return i.member(loc, m)
+			}
+		}
+		return location{}, fmt.Errorf("%s has no member %q", typ.Label, name)
+	}
+
+	key := step[1 : len(step)-1]
+	switch {
+	case typ.Encoding == EncodingMapping:
+		keyType, err := i.layout.typeOf(typ.Key)
+		if err != nil {
+			return location{}, err
+		}
+		encoded, err := EncodeKey(keyType, key)
+		if err != nil {
+			return location{}, err
+		}
+		valueType, err := i.layout.typeOf(typ.Value)
+		if err != nil {
+			return location{}, err
+		}
+		return location{typ: valueType, slot: MappingSlot(encoded, loc.slot)}, nil
+
+	case typ.Encoding == EncodingDynamicArray || typ.Base != "":
+		index, err := strconv.ParseUint(key, 0, 64)
+		if err != nil {
+			return location{}, fmt.Errorf("invalid array index %q", key)
+		}
+		baseType, err := i.layout.typeOf(typ.Base)
+		if err != nil {
+			return location{}, err
+		}
+		start := loc.slot
+		if typ.Encoding == EncodingDynamicArray {
+			word, err := i.word(ctx, loc.slot, sel)
+			if err != nil {
+				return location{}, err
+			}
+			if length := word.Big(); !length.IsUint64() || index >= length.Uint64() {
+				return location{}, fmt.Errorf("index %d out of range, length %s", index, length)
+			}
+			start = DataSlot(loc.slot)
+		} else if length, _ := typ.StaticLength(); index >= length {
+			return location{}, fmt.Errorf("index %d out of range, length %d", index, length)
+		}
+		slots, offset := ElementPosition(index, baseType.Size())
+		return location{typ: baseType, slot: AddSlot(start, slots), offset: offset}, nil
+	}
+	return location{}, fmt.Errorf("cannot index %s", typ.Label)
+}
+
+func (i *Inspector) read(ctx context.Context, path string, loc location, sel blockref.Selector) (*Value, error) {
+	value := &Value{Path: path, Type: loc.typ.Label, Slot: loc.slot, Offset: loc.offset}
+	switch {
+	case loc.typ.Encoding == EncodingMapping:
+		// 映射本身不占用数据，只能按 key 访问，Decoded 保持为 nil
+	case loc.typ.Encoding == EncodingBytes:
+		data, err := i.readBytes(ctx, loc.slot, sel)
+		if err != nil {
+			return nil, err
+		}
+		if loc.typ.Label == "string" {
+			value.Decoded = string(data)
+		} else {
+			value.Decoded = data
+		}
+	case loc.typ.Encoding == EncodingDynamicArray:
+		word, err := i.word(ctx, loc.slot, sel)
+		if err != nil {
+			return nil, err
+		}
+		value.Decoded = word.Big()
+	case len(loc.typ.Members) > 0:
+		members := make([]*Value, len(loc.typ.Members))
+		for n, m := range loc.typ.Members {
+			ml, err := i.member(loc, m)
+			if err != nil {
+				return nil, err
+			}
+			if members[n], err = i.read(ctx, path+"."+m.Label, ml, sel); err != nil {
+				return nil, err
+			}
+		}
+		value.Decoded = members
+	case loc.typ.Base != "":
+		length, _ := loc.typ.StaticLength()
+		value.Decoded = new(big.Int).SetUint64(length)
+	default:
+		word, err := i.word(ctx, loc.slot, sel)
+		if err != nil {
+			return nil, err
+		}
+		size := loc.typ.Size()
+		if loc.offset+size > common.HashLength {
+			return nil, fmt.Errorf("value of %d bytes at offset %d overflows slot", size, loc.offset)
+		}
+		decoded, err := DecodeValue(loc.typ, Extract(word, loc.offset, size))
+		if err != nil {
+			return nil, err
+		}
+		value.Decoded = decoded
+	}
+	return value, nil
+}
+
+// readBytes 读取 string/bytes：短值直接取自槽内，长值从 keccak256(slot) 起连续读取
+func (i *Inspector) readBytes(ctx context.Context, slot common.Hash, sel blockref.Selector) ([]byte, error) {
+	word, err := i.word(ctx, slot, sel)
+	if err != nil {
+		return nil, err
+	}
+	length, short, err := BytesLength(word)
+	if err != nil {
+		return nil, fmt.Errorf("storage slot %s: %w", slot.Hex(), err)
+	}
+	if short {
+		return common.CopyBytes(word[:length]), nil
+	}
+	data := make([]byte, 0, length+31)
+	start := DataSlot(slot)
+	for n := uint64(0); uint64(len(data)) < length; n++ {
+		chunk, err := i.word(ctx, AddSlot(start, new(big.Int).SetUint64(n)), sel)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk[:]...)
+	}
+	return data[:length], nil
+}
+
+func (i *Inspector) word(ctx context.Context, slot common.Hash, sel blockref.Selector) (common.Hash, error) {
+	raw, err := i.reader.StorageAt(ctx, i.contract, slot, sel)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("storage slot %s: %w", slot.Hex(), err)
+	}
+	return common.BytesToHash(raw), nil
+}
+
+// splitPath 把 users[3].name 拆成 "users" 和 ["[3]", ".name"]
+func splitPath(path string) (string, []string, error) {
+	end := strings.IndexAny(path, "[.")
+	if end < 0 {
+		return path, nil, nil
+	}
+	name, rest := path[:end], path[end:]
+	if name == "" {
+		return "", nil, fmt.Errorf("invalid path %q", path)
+	}
+	var steps []string
+	for rest != "" {
+		switch rest[0] {
+		case '[':
+			closing := strings.IndexByte(rest, ']')
+			if closing < 0 {
+				return "", nil, fmt.Errorf("unclosed '[' in path %q", path)
+			}
+			steps = append(steps, rest[:closing+1])
+			rest = rest[closing+1:]
+		case '.':
+			next := strings.IndexAny(rest[1:], "[.")
+			if next < 0 {
+				next = len(rest) - 1
+			}
+			if next == 0 {
+				return "", nil, fmt.Errorf("empty member in path %q", path)
+			}
+			steps = append(steps, rest[:next+1])
+			rest = rest[next+1:]
+		default:
+			return "", nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	return name, steps, nil
+}
+
+// String 格式化输出
+func (v *Value) String() string {
+	switch d := v.Decoded.(type) {
+	case nil:
+		return fmt.Sprintf("%s (%s)  [slot %s]", v.Path, v.Type, v.Slot.Hex())
+	case []*Value:
+		parts := make([]string, 0, len(d))
+		for _, m := range d {
+			parts = append(parts, m.String())
+		}
+		return strings.Join(parts, "\n")
+	case string:
+		return fmt.Sprintf("%s (%s) = %q  [slot %s]", v.Path, v.Type, d, v.Slot.Hex())
+	}
+	return fmt.Sprintf("%s (%s) = %v  [slot %s offset %d]", v.Path, v.Type, v.Decoded, v.Slot.Hex(), v.Offset)
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+// Encoding 取值与 solc storageLayout 中 types[].encoding 一致
+const (
+	EncodingInplace      = "inplace"       // 值类型、定长数组、结构体，直接占用槽位
+	EncodingMapping      = "mapping"       // 映射，元素位于 keccak256(key . slot)
+	EncodingDynamicArray = "dynamic_array" // 动态数组，槽中存长度，元素从 keccak256(slot) 开始
+	EncodingBytes        = "bytes"         // string / bytes，短值与长度存于同一槽，长值另存
+)
+
+// Variable 一个状态变量或结构体成员
+type Variable struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"` // 槽内从低位开始的字节偏移
+	Slot   string `json:"slot"`   // 十进制槽号，结构体成员为相对结构体起始槽的偏移
+	Type   string `json:"type"`   // types 中的类型 ID
+}
+
+// Type storageLayout 中的类型描述
+type Type struct {
+	Encoding      string     `json:"encoding"`
+	Label         string     `json:"label"`
+	NumberOfBytes string     `json:"numberOfBytes"`
+	Key           string     `json:"key,omitempty"`
+	Value         string     `json:"value,omitempty"`
+	Base          string     `json:"base,omitempty"`
+	Members       []Variable `json:"members,omitempty"`
+}
+
+// Layout solc --storage-layout 输出中的 storageLayout 对象，也可以手写同样格式的 JSON
+type Layout struct {
+	Storage []Variable       `json:"storage"`
+	Types   map[string]*Type `json:"types"`
+}
+
+//go:embed store_layout.json
+var storeLayoutJSON []byte
+
+// StoreLayout Store 合约的存储布局：version 位于槽 0，items 位于槽 1
+func StoreLayout() *Layout {
+	layout, err := ParseLayout(storeLayoutJSON)
+	if err != nil {
+		panic(err)
+	}
+	return layout
+}
+
+// ParseLayout 解析存储布局，既接受 storageLayout 对象本身，也接受包含 storageLayout 字段的合约编译输出
+func ParseLayout(data []byte) (*Layout, error) {
+	var wrapper struct {
+		StorageLayout *Layout `json:"storageLayout"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err == nil && wrapper.StorageLayout != nil {
+		return wrapper.StorageLayout, wrapper.StorageLayout.check()
+	}
+	var layout Layout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("parse storage layout: %w", err)
+	}
+	return &layout, layout.check()
+}
+
+// ReadLayout 从 reader 读取存储布局
+func ReadLayout(r io.Reader) (*Layout, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLayout(data)
+}
+
+// LoadLayout 从文件读取存储布局
+func LoadLayout(path string) (*Layout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLayout(data)
+}
+
+// check 检查变量引用的类型都存在
+func (l *Layout) check() error {
+	if len(l.Storage) == 0 {
+		return fmt.Errorf("storage layout has no variables")
+	}
+	for _, v := range l.Storage {
+		if _, err := l.typeOf(v.Type); err != nil {
+			return fmt.Errorf("variable %s: %w", v.Label, err)
+		}
+	}
+	return nil
+}
+
+// Variable 按名称查找状态变量
+func (l *Layout) Variable(label string) (Variable, bool) {
+	for _, v := range l.Storage {
+		if v.Label == label {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+func (l *Layout) typeOf(id string) (*Type, error) {
+	t, ok := l.Types[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", id)
+	}
+	return t, nil
+}
+
+// SlotNumber 解析十进制槽号
+func (v Variable) SlotNumber() (*big.Int, error) {
+	slot, ok := new(big.Int).SetString(v.Slot, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid slot %q for %s", v.Slot, v.Label)
+	}
+	return slot, nil
+}
+
+// Size 类型占用的字节数
+func (t *Type) Size() int {
+	n, _ := strconv.Atoi(t.NumberOfBytes)
+	return n
+}
+
+var staticArrayLength = regexp.MustCompile(`\[(\d+)\]$`)
+
+// StaticLength 定长数组的长度，不是定长数组时返回 false
+func (t *Type) StaticLength() (uint64, bool) {
+	if t.Encoding != EncodingInplace || t.Base == "" {
+		return 0, false
+	}
+	m := staticArrayLength.FindStringSubmatch(t.Label)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(m[1], 10, 64)
+	return n, err == nil
+}
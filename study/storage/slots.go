@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"errors"
+	"eth-client-study/utils"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MappingSlot 计算映射元素的槽位：keccak256(key . slot)
+// key 需按 EncodeKey 编码：值类型补齐到 32 字节，string/bytes 为原始字节
+func MappingSlot(key []byte, slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(key, slot.Bytes())
+}
+
+// DataSlot 动态数组元素或长 string/bytes 数据的起始槽位：keccak256(slot)
+func DataSlot(slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(slot.Bytes())
+}
+
+// AddSlot 返回 slot + n
+func AddSlot(slot common.Hash, n *big.Int) common.Hash {
+	sum := new(big.Int).Add(slot.Big(), n)
+	return common.BigToHash(math.U256(sum))
+}
+
+// ElementPosition 计算数组第 index 个元素相对数组数据起始槽的槽偏移和槽内字节偏移
+// 元素不超过 16 字节时多个元素打包进同一槽，否则每个元素占 ceil(size/32) 个槽
+func ElementPosition(index uint64, size int) (*big.Int, int) {
+	if size <= 16 {
+		perSlot := uint64(32 / size)
+		return new(big.Int).SetUint64(index / perSlot), int(index%perSlot) * size
+	}
+	slots := uint64((size + 31) / 32)
+	return new(big.Int).Mul(new(big.Int).SetUint64(index), new(big.Int).SetUint64(slots)), 0
+}
+
+// Extract 取出槽内从低位 offset 字节开始、长度为 size 的打包值
+func Extract(word common.Hash, offset, size int) []byte {
+	end := common.HashLength - offset
+	return common.CopyBytes(word[end-size : end])
+}
+
+// MaxBytesLength 读取长 string/bytes 的上限，每 32 字节需要一次 StorageAt 调用
+const MaxBytesLength = 1 << 16
+
+// ErrBytesHeader 槽值不是合法的 string/bytes 头，通常是布局与合约的实际存储不符
+var ErrBytesHeader = errors.New("invalid string/bytes slot header")
+
+// BytesLength 解析 string/bytes 的槽值：短值（< 32 字节）最低位为 0，长度*2 存于最低字节，数据左对齐存于同一槽；
+// 长值最低位为 1，槽中存 长度*2+1，数据从 keccak256(slot) 开始连续存放
+// 短值长度超过 31、长值长度不足 32 或超过 MaxBytesLength 时返回 ErrBytesHeader
+func BytesLength(word common.Hash) (length uint64, short bool, err error) {
+	if word[31]&1 == 0 {
+		length = uint64(word[31]) / 2
+		if length >= common.HashLength {
+			return 0, false, fmt.Errorf("%w: short length %d", ErrBytesHeader, length)
+		}
+		return length, true, nil
+	}
+	n := new(big.Int).Rsh(word.Big(), 1)
+	if !n.IsUint64() || n.Uint64() < common.HashLength {
+		return 0, false, fmt.Errorf("%w: long length %s", ErrBytesHeader, n)
+	}
+	if n.Uint64() > MaxBytesLength {
+		return 0, false, fmt.Errorf("%w: length %d exceeds %d", ErrBytesHeader, n.Uint64(), MaxBytesLength)
+	}
+	return n.Uint64(), false, nil
+}
+
+// EncodeKey 按映射键类型把字符串形式的 key 编码为计算槽位所需的字节
+func EncodeKey(t *Type, key string) ([]byte, error) {
+	label := t.Label
+	switch {
+	case label == "string":
+		return []byte(key), nil
+	case label == "bytes":
+		return hexutil.Decode(key)
+	case label == "bool":
+		b, err := strconv.ParseBool(key)
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			return common.BigToHash(big.NewInt(1)).Bytes(), nil
+		}
+		return common.Hash{}.Bytes(), nil
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		address, err := utils.ParseAddress(key)
+		if err != nil {
+			return nil, fmt.Errorf("address key: %w", err)
+		}
+		return common.LeftPadBytes(address.Bytes(), 32), nil
+	case strings.HasPrefix(label, "bytes"):
+		// bytesN 在内存/ABI 中左对齐，右侧补零
+		raw, err := hexutil.Decode(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) > t.Size() {
+			return nil, fmt.Errorf("key %q longer than %s", key, label)
+		}
+		return common.RightPadBytes(raw, 32), nil
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "int") || strings.HasPrefix(label, "enum "):
+		n, ok := math.ParseBig256(key)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer key %q", key)
+		}
+		return common.BigToHash(math.U256(n)).Bytes(), nil
+	}
+	return nil, fmt.Errorf("unsupported mapping key type %s", label)
+}
+
+// DecodeValue 按值类型解码槽内取出的字节
+func DecodeValue(t *Type, raw []byte) (interface{}, error) {
+	label := t.Label
+	switch {
+	case label == "bool":
+		return new(big.Int).SetBytes(raw).Sign() != 0, nil
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		return common.BytesToAddress(raw), nil
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(raw), nil
+	case strings.HasPrefix(label, "int"):
+		n := new(big.Int).SetBytes(raw)
+		return signExtend(n, len(raw)), nil
+	case strings.HasPrefix(label, "bytes"):
+		return hexutil.Bytes(raw), nil
+	}
+	return nil, fmt.Errorf("unsupported value type %s", label)
+}
+
+// signExtend 把 size 字节的补码解释为有符号数
+func signExtend(n *big.Int, size int) *big.Int {
+	bits := uint(size * 8)
+	if n.Bit(int(bits)-1) == 0 {
+		return n
+	}
+	return new(big.Int).Sub(n, new(big.Int).Lsh(big.NewInt(1), bits))
+}
@@ -0,0 +1,149 @@
+// store_mirror.go - 用 ItemSet 事件在本地重建 Store 合约的 items 并保持同步
+// 用法：
+//
+//	go run study/store_mirror.go -check 20
+//	go run study/store_mirror.go -block finalized -check 20
+//	go run study/store_mirror.go -key demo_save_key -at 5700000
+//	go run study/store_mirror.go -snapshot mirror.json -watch
+package main
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/blocktime"
+	"eth-client-study/study/reorg"
+	"eth-client-study/study/storekv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	storeFlag := flag.String("store", "store", "Store 合约地址或地址簿名称")
+	fromFlag := flag.Uint64("from", 0, "回放起始区块，0 表示二分查找合约部署区块")
+	blockFlag := flag.String("block", "", "回放截止的区块：latest、safe、finalized 或区块号，为空时使用 BLOCK_TAG（默认 latest）")
+	keyFlag := flag.String("key", "", "输出该 key 的写入历史")
+	atFlag := flag.Uint64("at", 0, "与 -key 一起使用，输出该区块时 key 的值")
+	checkFlag := flag.Int("check", 0, "随机抽取 N 个 key 与链上 Store.items 比对，-1 表示全部")
+	snapshotFlag := flag.String("snapshot", "", "快照文件，存在时从快照继续同步，结束时写回")
+	watchFlag := flag.Bool("watch", false, "同步完成后持续订阅新事件（按 Ctrl+C 退出）")
+	flag.Parse()
+
+	// 订阅事件需要 WebSocket 连接
+	client, err := ethclient.Dial("wss://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	book := addressbook.MustOpen(ctx, client)
+
+	address := book.MustResolve("-store", *storeFlag)
+	mirror, err := storekv.NewMirror(address, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *snapshotFlag != "" {
+		if f, err := os.Open(*snapshotFlag); err == nil {
+			err = mirror.Load(f)
+			f.Close()
+			if err != nil {
+				log.Fatal("读取快照失败：", err)
+			}
+			fmt.Printf("从快照恢复：%d 个 key，已同步到区块 %d\n", len(mirror.Keys()), mirror.Synced())
+		}
+	}
+
+	sel, err := blockref.ParseOrDefault(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	header, err := blockref.Header(ctx, client, sel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	head := header.Number.Uint64()
+	from := *fromFlag
+	if mirror.Synced() > 0 {
+		from = mirror.Synced() + 1
+	} else if from == 0 {
+		if from, err = blocktime.DeploymentBlock(ctx, client, address, head); err != nil {
+			log.Fatal("查找部署区块失败：", err)
+		}
+		fmt.Println("合约部署区块：", from)
+	}
+	if from <= head {
+		if err := mirror.Sync(ctx, from, head); err != nil {
+			log.Fatal("回放事件失败：", err)
+		}
+	}
+	fmt.Printf("已同步到区块 %d，共 %d 个 key\n", mirror.Synced(), len(mirror.Keys()))
+
+	if *keyFlag != "" {
+		key, err := storekv.String.Encode(*keyFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, w := range mirror.History(key) {
+			fmt.Printf("区块 %d 日志 %d 交易 %s：%s\n", w.Block, w.LogIndex, w.TxHash.Hex(), storekv.Display(storekv.String, w.Value))
+		}
+		if *atFlag > 0 {
+			value, ok := mirror.ValueAt(key, *atFlag)
+			fmt.Printf("区块 %d 时 %s = %s（已设置：%v）\n", *atFlag, *keyFlag, storekv.Display(storekv.String, value), ok)
+		}
+	} else {
+		for _, key := range mirror.Keys() {
+			value, _ := mirror.Get(key)
+			fmt.Printf("%s = %s\n", storekv.Display(storekv.String, key), storekv.Display(storekv.String, value))
+		}
+	}
+
+	if *checkFlag != 0 {
+		mismatches, err := mirror.Check(ctx, *checkFlag, mirror.Synced())
+		if err != nil {
+			log.Fatal("一致性检查失败：", err)
+		}
+		if len(mismatches) == 0 {
+			fmt.Println("一致性检查通过")
+		}
+		for _, mm := range mismatches {
+			fmt.Printf("不一致：key=%s 镜像=%s 链上=%s\n", mm.Key.Hex(), mm.Mirror.Hex(), mm.Chain.Hex())
+		}
+	}
+
+	if *watchFlag {
+		fmt.Println("开始订阅 ItemSet 事件...（按Ctrl+C退出）")
+		// 订阅推送的 Removed 日志只覆盖订阅期间的浅重组，另外跟踪链头，重组时回滚并重新同步
+		go func() {
+			err := reorg.NewTracker(client, reorg.DefaultWindow).Follow(ctx, client, func(e *reorg.Event) {
+				fmt.Println("检测到链重组，回滚并重新同步：", e)
+			}, mirror)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Println("链重组跟踪结束：", err)
+				stop()
+			}
+		}()
+		err := mirror.Watch(ctx, client.BlockNumber)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Println("订阅结束：", err)
+		}
+	}
+
+	if *snapshotFlag != "" {
+		f, err := os.Create(*snapshotFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		if err := mirror.Save(f); err != nil {
+			log.Fatal("保存快照失败：", err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package storekv
+
+import (
+	"eth-client-study/study/store"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Item Store 中的一条键值
+type Item struct {
+	Key      string // 按 KeyCodec 解码的 key，无法解码时为十六进制
+	Value    string // 按 ValueCodec 解码的 value，无法解码时为十六进制
+	RawKey   [32]byte
+	RawValue [32]byte
+	Block    uint64      // 最后一次写入所在区块
+	TxHash   common.Hash // 最后一次写入的交易
+}
+
+// Client 对 Store 合约的封装，统一用 KeyCodec / ValueCodec 在字符串与 bytes32 之间转换
+type Client struct {
+	contract *store.Store
+	address  common.Address
+
+	KeyCodec   Codec
+	ValueCodec Codec
+}
+
+// NewClient 创建客户端，key 和 value 默认都按 UTF-8 字符串编码
+func NewClient(address common.Address, backend bind.ContractBackend) (*Client, error) {
+	contract, err := store.NewStore(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{contract: contract, address: address, KeyCodec: String, ValueCodec: String}, nil
+}
+
+// Address 合约地址
+func (c *Client) Address() common.Address {
+	return c.address
+}
+
+// Contract 底层的 abigen 绑定
+func (c *Client) Contract() *store.Store {
+	return c.contract
+}
+
+// Version 合约版本
+func (c *Client) Version(opts *bind.CallOpts) (string, error) {
+	return c.contract.Version(opts)
+}
+
+// Set 编码后调用 setItem，key 或 value 超出 32 字节时在发送交易前返回错误
+func (c *Client) Set(opts *bind.TransactOpts, key, value string) (*types.Transaction, error) {
+	rawKey, err := c.KeyCodec.Encode(key)
+	if err != nil {
+		return nil, fmt.Errorf("encode key: %w", err)
+	}
+	rawValue, err := c.ValueCodec.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode value: %w", err)
+	}
+	return c.contract.SetItem(opts, rawKey, rawValue)
+}
+
+// Get 读取 key 对应的值；Store 无法区分“未设置”和“设置为全零”，两者都返回 found 为 false
+func (c *Client) Get(opts *bind.CallOpts, key string) (value string, found bool, err error) {
+	rawKey, err := c.KeyCodec.Encode(key)
+	if err != nil {
+		return "", false, fmt.Errorf("encode key: %w", err)
+	}
+	rawValue, err := c.contract.Items(opts, rawKey)
+	if err != nil {
+		return "", false, err
+	}
+	if rawValue == ([32]byte{}) {
+		return "", false, nil
+	}
+	value, err = c.ValueCodec.Decode(rawValue)
+	if err != nil {
+		return "", true, fmt.Errorf("decode value: %w", err)
+	}
+	return value, true, nil
+}
+
+// List 通过 ItemSet 事件列出区间内写入过的所有 key 及其最终值，按最后一次写入的先后排序
+// 合约没有提供枚举接口，事件是唯一的来源；opts.Start 应不晚于合约部署区块
+func (c *Client) List(opts *bind.FilterOpts) ([]Item, error) {
+	it, err := c.contract.FilterItemSet(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	latest := make(map[[32]byte]Item)
+	order := make(map[[32]byte][2]uint64) // 最后一次写入的 (区块号, 日志下标)
+	for it.Next() {
+		event := it.Event
+		latest[event.Key] = Item{
+			Key:      Display(c.KeyCodec, event.Key),
+			Value:    Display(c.ValueCodec, event.Value),
+			RawKey:   event.Key,
+			RawValue: event.Value,
+			Block:    event.Raw.BlockNumber,
+			TxHash:   event.Raw.TxHash,
+		}
+		order[event.Key] = [2]uint64{event.Raw.BlockNumber, uint64(event.Raw.Index)}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	items := make([]Item, 0, len(latest))
+	for _, item := range latest {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := order[items[i].RawKey], order[items[j].RawKey]
+		return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+	})
+	return items, nil
+}
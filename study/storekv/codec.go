@@ -0,0 +1,173 @@
+package storekv
+
+import (
+	"bytes"
+	"errors"
+	"eth-client-study/utils"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrTooLong 编码后超过 32 字节
+	ErrTooLong = errors.New("value does not fit in bytes32")
+	// ErrIrreversible 编码不可逆（如 keccak 哈希），无法从 bytes32 还原原始值
+	ErrIrreversible = errors.New("encoding is not reversible")
+)
+
+// Codec 在字符串与 Store 合约的 bytes32 键值之间转换
+type Codec interface {
+	Name() string
+	Encode(s string) ([32]byte, error)
+	Decode(b [32]byte) (string, error)
+}
+
+var (
+	String  Codec = stringCodec{}  // UTF-8 字符串，左对齐右补零，超过 32 字节报错
+	Hex     Codec = hexCodec{}     // 0x 十六进制字节，左对齐右补零（与 Solidity 的 bytes32(hex"...") 一致）
+	Uint    Codec = uintCodec{}    // 十进制或 0x 十六进制的无符号整数，大端右对齐（与 uint256 一致）
+	Address Codec = addressCodec{} // 地址，右对齐（与 bytes32(uint256(uint160(addr))) 一致）
+	Keccak  Codec = keccakCodec{}  // keccak256(UTF-8 字符串)，用于超过 32 字节的长 key，不可解码
+)
+
+// Codecs 所有内置编码，按名称索引
+var Codecs = map[string]Codec{
+	String.Name():  String,
+	Hex.Name():     Hex,
+	Uint.Name():    Uint,
+	Address.Name(): Address,
+	Keccak.Name():  Keccak,
+}
+
+// CodecByName 按名称查找编码，用于命令行参数
+func CodecByName(name string) (Codec, error) {
+	codec, ok := Codecs[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown codec %q (string, hex, uint, address, keccak)", name)
+	}
+	return codec, nil
+}
+
+type stringCodec struct{}
+
+func (stringCodec) Name() string { return "string" }
+
+func (stringCodec) Encode(s string) ([32]byte, error) {
+	var b [32]byte
+	if !utf8.ValidString(s) {
+		return b, fmt.Errorf("invalid UTF-8 string %q", s)
+	}
+	if len(s) > len(b) {
+		return b, fmt.Errorf("%w: %q is %d bytes", ErrTooLong, s, len(s))
+	}
+	if strings.IndexByte(s, 0) >= 0 {
+		return b, fmt.Errorf("string %q contains NUL byte", s)
+	}
+	copy(b[:], s)
+	return b, nil
+}
+
+// Decode 去掉末尾补齐的 0 字节；不是合法 UTF-8 时返回错误，调用方可以改用 Hex 显示
+func (stringCodec) Decode(b [32]byte) (string, error) {
+	trimmed := bytes.TrimRight(b[:], "\x00")
+	if bytes.IndexByte(trimmed, 0) >= 0 || !utf8.Valid(trimmed) {
+		return "", fmt.Errorf("bytes32 %s is not a UTF-8 string", hexutil.Encode(b[:]))
+	}
+	return string(trimmed), nil
+}
+
+type hexCodec struct{}
+
+func (hexCodec) Name() string { return "hex" }
+
+func (hexCodec) Encode(s string) ([32]byte, error) {
+	var b [32]byte
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		s = "0x" + s
+	}
+	if len(s)%2 == 1 {
+		return b, fmt.Errorf("hex string %q has odd length", s)
+	}
+	raw, err := hexutil.Decode(s)
+	if err != nil {
+		return b, fmt.Errorf("invalid hex %q: %w", s, err)
+	}
+	if len(raw) > len(b) {
+		return b, fmt.Errorf("%w: %s is %d bytes", ErrTooLong, s, len(raw))
+	}
+	copy(b[:], raw)
+	return b, nil
+}
+
+func (hexCodec) Decode(b [32]byte) (string, error) {
+	return hexutil.Encode(b[:]), nil
+}
+
+type uintCodec struct{}
+
+func (uintCodec) Name() string { return "uint" }
+
+func (uintCodec) Encode(s string) ([32]byte, error) {
+	var b [32]byte
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return b, fmt.Errorf("invalid integer %q", s)
+	}
+	if n.Sign() < 0 {
+		return b, fmt.Errorf("negative integer %q", s)
+	}
+	if n.BitLen() > 256 {
+		return b, fmt.Errorf("%w: %s exceeds uint256", ErrTooLong, s)
+	}
+	n.FillBytes(b[:])
+	return b, nil
+}
+
+func (uintCodec) Decode(b [32]byte) (string, error) {
+	return new(big.Int).SetBytes(b[:]).String(), nil
+}
+
+type addressCodec struct{}
+
+func (addressCodec) Name() string { return "address" }
+
+func (addressCodec) Encode(s string) ([32]byte, error) {
+	address, err := utils.ParseAddress(s)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return common.BytesToHash(address.Bytes()), nil
+}
+
+func (addressCodec) Decode(b [32]byte) (string, error) {
+	if common.BytesToHash(b[12:]) != common.Hash(b) {
+		return "", fmt.Errorf("bytes32 %s is not an address", hexutil.Encode(b[:]))
+	}
+	return common.BytesToAddress(b[12:]).Hex(), nil
+}
+
+type keccakCodec struct{}
+
+func (keccakCodec) Name() string { return "keccak" }
+
+func (keccakCodec) Encode(s string) ([32]byte, error) {
+	return crypto.Keccak256Hash([]byte(s)), nil
+}
+
+func (keccakCodec) Decode(b [32]byte) (string, error) {
+	return "", fmt.Errorf("%w: %s", ErrIrreversible, hexutil.Encode(b[:]))
+}
+
+// Display 用 codec 解码，失败时回退为十六进制，适合打印
+func Display(codec Codec, b [32]byte) string {
+	if s, err := codec.Decode(b); err == nil {
+		return s
+	}
+	return hexutil.Encode(b[:])
+}
@@ -0,0 +1,235 @@
+package storekv
+
+import (
+	"context"
+	"encoding/json"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/replay"
+	"eth-client-study/study/store"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Write 一次 setItem 写入，对应一条 ItemSet 事件
+type Write struct {
+	Key      common.Hash `json:"key"`
+	Value    common.Hash `json:"value"`
+	Block    uint64      `json:"block"`
+	LogIndex uint        `json:"logIndex"`
+	TxHash   common.Hash `json:"txHash"`
+}
+
+func (w Write) before(o Write) bool {
+	return w.Block < o.Block || w.Block == o.Block && w.LogIndex < o.LogIndex
+}
+
+func (w Write) position() replay.Position {
+	return replay.Position{Block: w.Block, Index: w.LogIndex}
+}
+
+// Mirror 通过回放 ItemSet 事件在本地重建 Store.items，并保留每个 key 的完整写入历史
+// 先 Sync 回放部署以来的历史事件，再 Watch 订阅新事件保持同步；链重组时被移除的事件会被撤销
+// Sync、Watch、Rewind 等由 replay.Replayer 提供
+type Mirror struct {
+	*replay.Replayer[Write]
+
+	contract *store.Store
+	address  common.Address
+	topic    common.Hash // ItemSet 的 topic0
+
+	mu      sync.RWMutex
+	history map[common.Hash][]Write // key -> 按 (区块, 日志下标) 升序的写入
+}
+
+// NewMirror 创建镜像
+func NewMirror(address common.Address, backend bind.ContractBackend) (*Mirror, error) {
+	contract, err := store.NewStore(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := store.StoreMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	m := &Mirror{contract: contract, address: address, topic: parsed.Events["ItemSet"].ID, history: make(map[common.Hash][]Write)}
+	query := ethereum.FilterQuery{Addresses: []common.Address{address}, Topics: [][]common.Hash{{m.topic}}}
+	m.Replayer = replay.New(backend, query, replay.Handler[Write]{Parse: m.parse, Apply: m.apply, Revert: m.revert})
+	return m, nil
+}
+
+// Apply 记录一次写入，重复的事件（同一区块同一日志下标）会被忽略，乱序到达时按顺序插入
+func (m *Mirror) Apply(w Write) {
+	m.Replayer.Apply(w.position(), w)
+}
+
+// Revert 撤销一次写入（对应 Removed 为 true 的事件）
+func (m *Mirror) Revert(w Write) {
+	m.Replayer.Revert(w.position())
+}
+
+func (m *Mirror) parse(log types.Log) ([]Write, error) {
+	if len(log.Topics) == 0 || log.Topics[0] != m.topic {
+		return nil, nil
+	}
+	event, err := m.contract.ParseItemSet(log)
+	if err != nil {
+		return nil, fmt.Errorf("parse ItemSet: %w", err)
+	}
+	return []Write{toWrite(event)}, nil
+}
+
+func (m *Mirror) apply(w Write) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	writes := m.history[w.Key]
+	i := sort.Search(len(writes), func(i int) bool { return !writes[i].before(w) })
+	writes = append(writes, Write{})
+	copy(writes[i+1:], writes[i:])
+	writes[i] = w
+	m.history[w.Key] = writes
+}
+
+func (m *Mirror) revert(w Write) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	writes := m.history[w.Key]
+	for i := range writes {
+		if writes[i].position() == w.position() {
+			writes = append(writes[:i], writes[i+1:]...)
+			break
+		}
+	}
+	if len(writes) == 0 {
+		delete(m.history, w.Key)
+	} else {
+		m.history[w.Key] = writes
+	}
+}
+
+// Get 当前值，从未写入过时 ok 为 false
+func (m *Mirror) Get(key common.Hash) (common.Hash, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	writes := m.history[key]
+	if len(writes) == 0 {
+		return common.Hash{}, false
+	}
+	return writes[len(writes)-1].Value, true
+}
+
+// ValueAt 第 block 个区块执行完之后 key 的值，该区块之前从未写入过时 ok 为 false
+func (m *Mirror) ValueAt(key common.Hash, block uint64) (common.Hash, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	writes := m.history[key]
+	i := sort.Search(len(writes), func(i int) bool { return writes[i].Block > block })
+	if i == 0 {
+		return common.Hash{}, false
+	}
+	return writes[i-1].Value, true
+}
+
+// History key 的全部写入，按时间先后排列
+func (m *Mirror) History(key common.Hash) []Write {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Write(nil), m.history[key]...)
+}
+
+// Keys 所有写入过的 key，按字节序排列
+func (m *Mirror) Keys() []common.Hash {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]common.Hash, 0, len(m.history))
+	for key := range m.history {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Cmp(keys[j]) < 0 })
+	return keys
+}
+
+// Mismatch 镜像与链上不一致的 key
+type Mismatch struct {
+	Key    common.Hash
+	Mirror common.Hash
+	Chain  common.Hash
+}
+
+// Check 随机抽取 sample 个 key（<= 0 时检查全部），在 block 区块上比较镜像的值与 Store.Items 的返回值
+// block 应不超过 Synced，否则镜像可能尚未包含该区块的写入
+func (m *Mirror) Check(ctx context.Context, sample int, block uint64) ([]Mismatch, error) {
+	keys := m.Keys()
+	if sample > 0 && sample < len(keys) {
+		rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+		keys = keys[:sample]
+	}
+	opts := blockref.Number(block).CallOpts(ctx)
+	var mismatches []Mismatch
+	for _, key := range keys {
+		chain, err := m.contract.Items(opts, key)
+		if err != nil {
+			return nil, fmt.Errorf("items(%s): %w", key.Hex(), err)
+		}
+		mirror, _ := m.ValueAt(key, block)
+		if common.Hash(chain) != mirror {
+			mismatches = append(mismatches, Mismatch{Key: key, Mirror: mirror, Chain: chain})
+		}
+	}
+	return mismatches, nil
+}
+
+// snapshot 持久化格式
+type snapshot struct {
+	Address common.Address `json:"address"`
+	Synced  hexutil.Uint64 `json:"synced"`
+	Writes  []Write        `json:"writes"`
+}
+
+// Save 把镜像保存为 JSON 快照，重启后 Load 并从 Synced+1 继续同步，不必重新回放全部历史
+func (m *Mirror) Save(w io.Writer) error {
+	snap := snapshot{Address: m.address, Synced: hexutil.Uint64(m.Synced())}
+	m.mu.RLock()
+	for _, writes := range m.history {
+		snap.Writes = append(snap.Writes, writes...)
+	}
+	m.mu.RUnlock()
+	sort.Slice(snap.Writes, func(i, j int) bool { return snap.Writes[i].before(snap.Writes[j]) })
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snap)
+}
+
+// Load 从 JSON 快照恢复镜像
+func (m *Mirror) Load(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return err
+	}
+	if snap.Address != m.address {
+		return fmt.Errorf("snapshot is for %s, mirror is for %s", snap.Address.Hex(), m.address.Hex())
+	}
+	for _, w := range snap.Writes {
+		m.Apply(w)
+	}
+	m.Advance(uint64(snap.Synced))
+	return nil
+}
+
+func toWrite(event *store.StoreItemSet) Write {
+	return Write{
+		Key:      event.Key,
+		Value:    event.Value,
+		Block:    event.Raw.BlockNumber,
+		LogIndex: event.Raw.Index,
+		TxHash:   event.Raw.TxHash,
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"eth-client-study/study/reorg"
 	"fmt"
 	"time"
 
@@ -48,6 +50,9 @@ func main() {
 	defer sub.Unsubscribe() // 程序退出时取消订阅
 	fmt.Println("开始监听新区块...（按Ctrl+C退出）")
 
+	// 5. 跟踪最近的区块头，检测新区块是否与上一个链头衔接
+	tracker := reorg.NewTracker(client, reorg.DefaultWindow)
+
 	for {
 		select {
 		case err := <-sub.Err():
@@ -59,6 +64,24 @@ func main() {
 			fmt.Printf("区块号：%d\n", header.Number.Int64())
 			fmt.Printf("区块Hash：%s\n", header.Hash().Hex())
 
+			event, err := tracker.Add(context.Background(), header)
+			if errors.Is(err, reorg.ErrReorgTooDeep) {
+				fmt.Printf("检测到超出跟踪窗口的重组，已重置跟踪：%v\n", err)
+			} else if err != nil {
+				fmt.Printf("检查链重组失败：%v\n", err)
+			}
+			if event != nil && event.Deep {
+				fmt.Printf("检测到链重组：深度至少 %d，共同祖先不高于区块 %d，本地状态应从已确认的区块重新同步\n", event.Depth, event.RewindTo())
+			} else if event != nil {
+				fmt.Printf("检测到链重组：深度 %d，共同祖先 %d（%s）\n", event.Depth, event.Ancestor.Number.Uint64(), event.Ancestor.Hash().Hex())
+				for _, h := range event.Dropped {
+					fmt.Printf("  回滚区块：%d %s\n", h.Number.Uint64(), h.Hash().Hex())
+				}
+				for _, h := range event.Added {
+					fmt.Printf("  新增区块：%d %s\n", h.Number.Uint64(), h.Hash().Hex())
+				}
+			}
+
 			// 6. 获取完整区块数据（添加超时，避免阻塞）
 			blockCtx, blockCancel := context.WithTimeout(context.Background(), 5*time.Second)
 			block, err := client.BlockByHash(blockCtx, header.Hash())
@@ -86,6 +109,4 @@ func main() {
 
 		}
 	}
-
-	fmt.Println("Done")
 }
@@ -0,0 +1,141 @@
+// token_approvals.go - 列出账户授出的所有 ERC20 额度，标记无限授权，并可批量撤销或降低额度
+// 用法：
+//
+//	go run study/token_approvals.go -owner bob -from 5000000
+//	go run study/token_approvals.go -from 5000000 -unlimited -revoke
+//	go run study/token_approvals.go -from 5000000 -reduce 100
+//	go run study/token_approvals.go -owner bob -from 5000000 -block finalized
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/tokens"
+	"eth-client-study/utils"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	ownerFlag := flag.String("owner", "", "授权账户地址或地址簿名称，为空时使用 PRIVATE_KEY1 对应的地址")
+	fromFlag := flag.Uint64("from", 0, "扫描 Approval 事件的起始区块")
+	unlimitedFlag := flag.Bool("unlimited", false, "只处理无限授权")
+	revokeFlag := flag.Bool("revoke", false, "把列出的授权全部撤销（额度改为 0），需要 PRIVATE_KEY1")
+	reduceFlag := flag.String("reduce", "", "把列出的授权降低到该金额（按各代币精度解析，没有有效 decimals() 的代币跳过），需要 PRIVATE_KEY1")
+	blockFlag := flag.String("block", "", "扫描截止并查询额度的区块：latest、safe、finalized、区块号或区块哈希，为空时使用 BLOCK_TAG（默认 latest）")
+	flag.Parse()
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	book := addressbook.MustOpen(ctx, client)
+
+	var privateKey *ecdsa.PrivateKey
+	var owner common.Address
+	if *ownerFlag != "" {
+		owner = book.MustResolve("-owner", *ownerFlag)
+	}
+	if *ownerFlag == "" || *revokeFlag || *reduceFlag != "" {
+		if privateKey, err = crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1")); err != nil {
+			log.Fatal("privateKey err:", err)
+		}
+		signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+		// 撤销或降低额度只能由授权账户本人发送，-owner 与签名账户不一致时不能替换成签名账户继续处理
+		if *ownerFlag != "" && owner != signer {
+			log.Fatalf("-owner %s 与 PRIVATE_KEY1 对应的地址 %s 不一致，无法撤销或降低其授权", owner.Hex(), signer.Hex())
+		}
+		owner = signer
+	}
+
+	sel, err := blockref.ParseOrDefault(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 先把标签解析为具体区块，输出的区块范围与查询所用的状态一致
+	header, err := blockref.Header(ctx, client, sel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	head := header.Number.Uint64()
+	approvals, err := tokens.ScanApprovals(ctx, client, owner, *fromFlag, blockref.Hash(header.Hash(), false), 0)
+	if err != nil {
+		log.Fatal("扫描授权失败：", err)
+	}
+	var selected []tokens.Approval
+	for _, a := range approvals {
+		if !*unlimitedFlag || a.Unlimited {
+			selected = append(selected, a)
+		}
+	}
+	fmt.Printf("%s 在区块 [%d, %d] 内授出的有效额度：%d 个（显示 %d 个）\n", owner.Hex(), *fromFlag, head, len(approvals), len(selected))
+	for _, a := range selected {
+		allowance := a.Allowance.String()
+		if a.Unlimited {
+			allowance = "无限"
+		} else if t, err := tokens.NewToken(ctx, a.Token, client); err == nil {
+			allowance = t.Format(a.Allowance)
+		}
+		fmt.Printf("代币 %s spender %s 额度 %s（最后授权于区块 %d）\n", a.Token.Hex(), a.Spender.Hex(), allowance, a.Block)
+	}
+	if !*revokeFlag && *reduceFlag == "" {
+		return
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts.Context = ctx
+
+	// 各代币精度不同，按代币分组换算目标额度
+	byToken := make(map[common.Address][]tokens.Approval)
+	var order []common.Address
+	for _, a := range selected {
+		if _, ok := byToken[a.Token]; !ok {
+			order = append(order, a.Token)
+		}
+		byToken[a.Token] = append(byToken[a.Token], a)
+	}
+	for _, address := range order {
+		limit := new(big.Int)
+		if *reduceFlag != "" {
+			t, err := tokens.NewToken(ctx, address, client)
+			if err != nil {
+				log.Printf("代币 %s：%v", address.Hex(), err)
+				continue
+			}
+			if t.Quirks.Has(tokens.QuirkNoDecimals) || t.Quirks.Has(tokens.QuirkBadDecimals) {
+				log.Printf("代币 %s 没有有效的 decimals()（%s），无法确定金额精度，跳过", address.Hex(), t.Quirks)
+				continue
+			}
+			if limit, err = t.ParseAmount(*reduceFlag); err != nil {
+				log.Fatal(err)
+			}
+		}
+		txs, err := tokens.Reduce(opts, client, byToken[address], limit)
+		for i, a := range byToken[address] {
+			for _, tx := range txs[i] {
+				fmt.Printf("代币 %s spender %s：交易 %s\n", address.Hex(), a.Spender.Hex(), tx.Hash().Hex())
+			}
+		}
+		if err != nil {
+			log.Printf("代币 %s：%v", address.Hex(), err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// token_holders.go - 回放 ERC20 代币的 Transfer 事件，统计持有人余额和总供应量
+// 用法：
+//
+//	go run study/token_holders.go -top 20
+//	go run study/token_holders.go -address bob
+//	go run study/token_holders.go -reconcile 50
+//	go run study/token_holders.go -block finalized -reconcile 50
+package main
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/blockref"
+	"eth-client-study/study/blocktime"
+	"eth-client-study/study/holders"
+	"eth-client-study/study/reorg"
+	"eth-client-study/study/tokens"
+	"eth-client-study/utils"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	tokenFlag := flag.String("token", "mkt", "ERC20 代币地址或地址簿名称")
+	fromFlag := flag.Uint64("from", 0, "回放起始区块，0 表示二分查找代币部署区块")
+	blockFlag := flag.String("block", "", "回放截止的区块：latest、safe、finalized 或区块号，为空时使用 BLOCK_TAG（默认 latest）")
+	topFlag := flag.Int("top", 20, "输出余额最高的 N 个地址，-1 表示全部")
+	addressFlag := flag.String("address", "", "输出该地址或地址簿名称的余额和转账记录")
+	reconcileFlag := flag.Int("reconcile", 0, "随机抽取 N 个持有人与链上 balanceOf 对账，-1 表示全部")
+	watchFlag := flag.Bool("watch", false, "同步完成后持续订阅新的 Transfer 事件（按 Ctrl+C 退出）")
+	flag.Parse()
+
+	// 订阅事件需要 WebSocket 连接
+	client, err := ethclient.Dial("wss://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	book := addressbook.MustOpen(ctx, client)
+
+	address := book.MustResolve("-token", *tokenFlag)
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	md, err := tokens.NewMetadataFetcher(client, chainID.Uint64(), nil).Fetch(ctx, address)
+	if err != nil {
+		log.Fatal("读取代币信息失败：", err)
+	}
+	decimals, symbol := md.Decimals, md.Symbol
+	if md.Quirks != 0 {
+		fmt.Println("代币元数据不规范：", md.Quirks)
+	}
+	indexer, err := holders.NewIndexer(address, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sel, err := blockref.ParseOrDefault(*blockFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	header, err := blockref.Header(ctx, client, sel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	head := header.Number.Uint64()
+	from := *fromFlag
+	if from == 0 {
+		if from, err = blocktime.DeploymentBlock(ctx, client, address, head); err != nil {
+			log.Fatal("查找部署区块失败：", err)
+		}
+		fmt.Println("代币部署区块：", from)
+	}
+	if err := indexer.Sync(ctx, from, head); err != nil {
+		log.Fatal("回放事件失败：", err)
+	}
+	fmt.Printf("已同步到区块 %d，持有人 %d 个，总供应量 %s %s\n", indexer.Synced(), indexer.Holders(), utils.FormatUnits(indexer.TotalSupply(), decimals), symbol)
+
+	if *addressFlag != "" {
+		account := book.MustResolve("-address", *addressFlag)
+		fmt.Printf("%s 余额：%s %s\n", account.Hex(), utils.FormatUnits(indexer.Balance(account), decimals), symbol)
+		for _, t := range indexer.History(account) {
+			direction, counterparty := "转入", t.From
+			if t.From == account {
+				direction, counterparty = "转出", t.To
+			}
+			fmt.Printf("区块 %d 交易 %s：%s %s %s（对方 %s）\n", t.Block, t.TxHash.Hex(), direction, utils.FormatUnits(t.Value, decimals), symbol, counterparty.Hex())
+		}
+	} else if *topFlag != 0 {
+		for i, h := range indexer.TopHolders(*topFlag) {
+			fmt.Printf("%3d. %s %s %s\n", i+1, h.Address.Hex(), utils.FormatUnits(h.Balance, decimals), symbol)
+		}
+	}
+
+	if *reconcileFlag != 0 {
+		report, err := indexer.Reconcile(ctx, *reconcileFlag)
+		if err != nil {
+			log.Fatal("对账失败：", err)
+		}
+		fmt.Printf("区块 %d 对账：检查 %d 个地址，不一致 %d 个，判定为 %s\n", report.Block, report.Checked, len(report.Discrepancies), report.Kind)
+		fmt.Printf("总供应量：事件 %s，链上 %s\n", utils.FormatUnits(report.IndexedSupply, decimals), utils.FormatUnits(report.ChainSupply, decimals))
+		for _, d := range report.Discrepancies {
+			fmt.Printf("不一致：%s 事件=%s 链上=%s\n", d.Address.Hex(), utils.FormatUnits(d.Indexed, decimals), utils.FormatUnits(d.Chain, decimals))
+		}
+	}
+
+	if *watchFlag {
+		fmt.Println("开始订阅 Transfer 事件...（按Ctrl+C退出）")
+		// 订阅推送的 Removed 日志只覆盖订阅期间的浅重组，另外跟踪链头，重组时回滚并重新同步
+		go func() {
+			err := reorg.NewTracker(client, reorg.DefaultWindow).Follow(ctx, client, func(e *reorg.Event) {
+				fmt.Println("检测到链重组，回滚并重新同步：", e)
+			}, indexer)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Println("链重组跟踪结束：", err)
+				stop()
+			}
+		}()
+		err := indexer.Watch(ctx, client.BlockNumber)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Println("订阅结束：", err)
+		}
+		fmt.Printf("已同步到区块 %d，持有人 %d 个\n", indexer.Synced(), indexer.Holders())
+	}
+}
@@ -0,0 +1,59 @@
+// token_metadata.go - 读取 ERC20 代币的名称、符号和精度，并报告不符合 IERC20Metadata 的地方
+// 兼容 name/symbol 返回 bytes32 的代币（如主网 MKR 0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2）和没有 decimals 的代币
+// 用法：
+//
+//	go run study/token_metadata.go 0x2f8C29909a2697E4E0449662302aAa1750f2cF98 0x...
+//	go run study/token_metadata.go -default-decimals 0 0x...
+package main
+
+import (
+	"context"
+	"eth-client-study/study/tokens"
+	"eth-client-study/utils"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	defaultDecimalsFlag := flag.Uint("default-decimals", uint(tokens.DefaultDecimals), "代币没有 decimals() 时使用的精度")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("用法：go run study/token_metadata.go <代币地址>...")
+	}
+	if *defaultDecimalsFlag > 255 {
+		log.Fatal("-default-decimals 不能超过 255")
+	}
+
+	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
+	}
+	fetcher := tokens.NewMetadataFetcher(client, chainID.Uint64(), nil)
+	fetcher.DefaultDecimals = uint8(*defaultDecimalsFlag)
+	for _, arg := range flag.Args() {
+		address, err := utils.ParseAddress(arg)
+		if err != nil {
+			log.Printf("%s：%v", arg, err)
+			continue
+		}
+		if !utils.HasChecksum(arg) {
+			log.Printf("警告：%s 没有校验和，请核对是否为 %s", arg, address.Hex())
+		}
+		md, err := fetcher.Fetch(ctx, address)
+		if err != nil {
+			log.Printf("%s：%v", arg, err)
+			continue
+		}
+		fmt.Printf("%s\n  名称：%q\n  符号：%q\n  精度：%d\n  非标准：%s\n", md.Address.Hex(), md.Name, md.Symbol, md.Decimals, md.Quirks)
+	}
+}
@@ -0,0 +1,215 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/blockref"
+	token "eth-client-study/study/erc20"
+	"eth-client-study/study/replay"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// approvalTopic Approval(address,address,uint256) 的事件签名，ERC721 的 Approval 签名相同但第三个参数也是 indexed
+var approvalTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+// UnlimitedThreshold 不小于该值的额度视为无限授权
+// 钱包通常授权 2^256-1，而 UNI、COMP 等代币会把它截断为 2^96-1 存储，因此取后者作为阈值
+var UnlimitedThreshold = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 96), common.Big1)
+
+// IsUnlimited 额度是否为无限授权
+func IsUnlimited(allowance *big.Int) bool {
+	return allowance.Cmp(UnlimitedThreshold) >= 0
+}
+
+// Approval owner 授予 spender 的一笔仍然有效的额度
+type Approval struct {
+	Token     common.Address
+	Owner     common.Address
+	Spender   common.Address
+	Allowance *big.Int    // 当前链上的 allowance，可能因 transferFrom 而小于事件中的值
+	Unlimited bool        // 见 IsUnlimited
+	Block     uint64      // 最后一次 Approval 事件所在区块
+	TxHash    common.Hash // 最后一次 Approval 事件的交易
+}
+
+// ScanBackend ScanApprovals 所需的接口，*ethclient.Client 和模拟链的客户端都满足
+type ScanBackend interface {
+	bind.ContractBackend
+	blockref.HeaderByNumberReader
+}
+
+// ScanApprovals 扫描 [from, at] 内 owner 发出的所有 Approval 事件（不限代币合约），
+// 再逐个查询 at 区块时的 allowance，返回额度不为零的授权，按代币和 spender 排序
+// at 先解析为具体区块，事件和 allowance 都以该区块为准；chunk 为每次 eth_getLogs 查询的区块数，0 表示 replay.DefaultChunkSize
+func ScanApprovals(ctx context.Context, backend ScanBackend, owner common.Address, from uint64, at blockref.Selector, chunk uint64) ([]Approval, error) {
+	head, err := blockref.Header(ctx, backend, at)
+	if err != nil {
+		return nil, err
+	}
+	to := head.Number.Uint64()
+	type pair struct{ token, spender common.Address }
+	latest := make(map[pair]types.Log)
+	query := ethereum.FilterQuery{Topics: [][]common.Hash{{approvalTopic}, {common.BytesToHash(owner.Bytes())}}}
+	err = replay.FilterLogs(ctx, backend, query, from, to, chunk, func(logs []types.Log, _ uint64) error {
+		for _, log := range logs {
+			// 跳过 ERC721 的 Approval(owner, approved, tokenId)：它有 4 个 topic 且 data 为空
+			if len(log.Topics) != 3 || len(log.Data) != 32 {
+				continue
+			}
+			latest[pair{log.Address, common.BytesToAddress(log.Topics[2].Bytes())}] = log
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("filter Approval: %w", err)
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	caller := blockref.Bind(backend, blockref.Hash(head.Hash(), false))
+	var approvals []Approval
+	for p, log := range latest {
+		instance, err := token.NewErc20Caller(p.token, caller)
+		if err != nil {
+			return nil, err
+		}
+		allowance, err := instance.Allowance(opts, owner, p.spender)
+		if err != nil {
+			return nil, fmt.Errorf("allowance on %s: %w", p.token.Hex(), err)
+		}
+		if allowance.Sign() == 0 {
+			continue
+		}
+		approvals = append(approvals, Approval{
+			Token:     p.token,
+			Owner:     owner,
+			Spender:   p.spender,
+			Allowance: allowance,
+			Unlimited: IsUnlimited(allowance),
+			Block:     log.BlockNumber,
+			TxHash:    log.TxHash,
+		})
+	}
+	sort.Slice(approvals, func(i, j int) bool {
+		if c := approvals[i].Token.Cmp(approvals[j].Token); c != 0 {
+			return c < 0
+		}
+		return approvals[i].Spender.Cmp(approvals[j].Spender) < 0
+	})
+	return approvals, nil
+}
+
+// Allowance 查询 owner 授予 spender 的最新额度
+func (t *Token) Allowance(ctx context.Context, owner, spender common.Address) (*big.Int, error) {
+	return t.contract.Allowance(&bind.CallOpts{Context: ctx}, owner, spender)
+}
+
+// SimulateApprove 以 from 的身份 eth_call 一次 approve，回滚或返回 false 时返回错误
+func (t *Token) SimulateApprove(ctx context.Context, from, spender common.Address, amount *big.Int) error {
+	data, err := t.abi.Pack("approve", spender, amount)
+	if err != nil {
+		return err
+	}
+	return t.simulate(ctx, from, data)
+}
+
+// Approve 模拟执行后发送 approve 交易，opts.From 为 owner
+func (t *Token) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := t.SimulateApprove(ctx, opts.From, spender, amount); err != nil {
+		return nil, fmt.Errorf("simulate approve: %w", err)
+	}
+	return t.contract.Approve(opts, spender, amount)
+}
+
+// SafeApprove 把额度改为 amount，额度已经等于 amount 时不发送交易
+// USDT 等代币要求先把非零额度清零才能设置新的非零额度（防止 approve 抢跑），
+// 直接 approve 模拟失败且当前额度不为零时，先发送 approve(0) 并等待上链，再发送 approve(amount)
+func (t *Token) SafeApprove(opts *bind.TransactOpts, backend bind.DeployBackend, spender common.Address, amount *big.Int) ([]*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	current, err := t.Allowance(ctx, opts.From, spender)
+	if err != nil {
+		return nil, fmt.Errorf("allowance: %w", err)
+	}
+	if current.Cmp(amount) == 0 {
+		return nil, nil
+	}
+	direct := t.SimulateApprove(ctx, opts.From, spender, amount)
+	if direct == nil {
+		tx, err := t.contract.Approve(opts, spender, amount)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Transaction{tx}, nil
+	}
+	if current.Sign() == 0 || amount.Sign() == 0 {
+		return nil, fmt.Errorf("simulate approve: %w", direct)
+	}
+
+	reset, err := t.Approve(opts, spender, common.Big0)
+	if err != nil {
+		return nil, fmt.Errorf("reset allowance: %w", err)
+	}
+	txs := []*types.Transaction{reset}
+	receipt, err := bind.WaitMined(ctx, backend, reset)
+	if err != nil {
+		return txs, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return txs, fmt.Errorf("reset allowance: transaction %s reverted", reset.Hash().Hex())
+	}
+	tx, err := t.Approve(opts, spender, amount)
+	if err != nil {
+		return txs, err
+	}
+	return append(txs, tx), nil
+}
+
+// Backend 发送交易并等待回执所需的接口，*ethclient.Client 和模拟链的客户端都满足
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// Reduce 把 approvals 中额度超过 limit 的授权逐个降为 limit，limit 为 0 即撤销
+// 单个代币失败不会中断后续处理，返回每个授权对应的交易（未处理时为空）以及合并后的错误
+func Reduce(opts *bind.TransactOpts, backend Backend, approvals []Approval, limit *big.Int) ([][]*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	txs := make([][]*types.Transaction, len(approvals))
+	var errs []error
+	for i, a := range approvals {
+		if a.Owner != opts.From {
+			errs = append(errs, fmt.Errorf("%s on %s: %w", a.Spender.Hex(), a.Token.Hex(), ErrOwnerMismatch))
+			continue
+		}
+		if a.Allowance.Cmp(limit) <= 0 {
+			continue
+		}
+		t, err := NewToken(ctx, a.Token, backend)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("token %s: %w", a.Token.Hex(), err))
+			continue
+		}
+		txs[i], err = t.SafeApprove(opts, backend, a.Spender, limit)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s on %s: %w", a.Spender.Hex(), a.Token.Hex(), err))
+		}
+	}
+	return txs, errors.Join(errs...)
+}
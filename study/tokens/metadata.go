@@ -0,0 +1,252 @@
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultDecimals 代币没有实现 decimals() 时使用的精度
+var DefaultDecimals uint8 = 18
+
+// ErrNotContract 地址上没有合约代码
+var ErrNotContract = errors.New("no contract code at address")
+
+var (
+	nameSelector     = crypto.Keccak256([]byte("name()"))[:4]
+	symbolSelector   = crypto.Keccak256([]byte("symbol()"))[:4]
+	decimalsSelector = crypto.Keccak256([]byte("decimals()"))[:4]
+	stringType, _    = abi.NewType("string", "", nil)
+)
+
+// Quirks 代币元数据与 IERC20Metadata 不一致的地方，按位组合
+type Quirks uint
+
+const (
+	// QuirkBytes32Name name() 返回 bytes32 而不是 string（如 MKR）
+	QuirkBytes32Name Quirks = 1 << iota
+	// QuirkBytes32Symbol symbol() 返回 bytes32 而不是 string
+	QuirkBytes32Symbol
+	// QuirkNoName 没有 name() 或返回值无法解码
+	QuirkNoName
+	// QuirkNoSymbol 没有 symbol() 或返回值无法解码
+	QuirkNoSymbol
+	// QuirkNoDecimals 没有 decimals()，精度取默认值
+	QuirkNoDecimals
+	// QuirkBadDecimals decimals() 的返回值超出 uint8，精度取默认值
+	QuirkBadDecimals
+)
+
+var quirkNames = []string{"bytes32-name", "bytes32-symbol", "no-name", "no-symbol", "no-decimals", "bad-decimals"}
+
+// Has 是否包含 flag 中的全部标记
+func (q Quirks) Has(flag Quirks) bool {
+	return q&flag == flag
+}
+
+// List 各标记的名称
+func (q Quirks) List() []string {
+	var names []string
+	for i, name := range quirkNames {
+		if q&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (q Quirks) String() string {
+	if q == 0 {
+		return "none"
+	}
+	return strings.Join(q.List(), ",")
+}
+
+// Metadata 代币的名称、符号和精度
+type Metadata struct {
+	ChainID  uint64
+	Address  common.Address
+	Name     string
+	Symbol   string
+	Decimals uint8
+	Quirks   Quirks
+}
+
+// MetadataCache 按 (链 ID, 代币地址) 缓存元数据，可在多个 MetadataFetcher 之间共享
+type MetadataCache struct {
+	mu      sync.RWMutex
+	entries map[metadataKey]Metadata
+}
+
+type metadataKey struct {
+	chainID uint64
+	address common.Address
+}
+
+// NewMetadataCache 创建空缓存
+func NewMetadataCache() *MetadataCache {
+	return &MetadataCache{entries: make(map[metadataKey]Metadata)}
+}
+
+// Get 读取缓存
+func (c *MetadataCache) Get(chainID uint64, address common.Address) (Metadata, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	md, ok := c.entries[metadataKey{chainID, address}]
+	return md, ok
+}
+
+// Put 写入缓存
+func (c *MetadataCache) Put(md Metadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[metadataKey{md.ChainID, md.Address}] = md
+}
+
+// MetadataFetcher 读取代币元数据，兼容返回 bytes32 的 name/symbol 和缺少 decimals 的代币
+type MetadataFetcher struct {
+	caller  bind.ContractCaller
+	chainID uint64
+	cache   *MetadataCache
+
+	DefaultDecimals uint8 // 缺少 decimals() 时使用的精度，初始为包级 DefaultDecimals
+}
+
+// NewMetadataFetcher 创建读取 chainID 上代币元数据的 fetcher，cache 为 nil 时使用独立的缓存
+func NewMetadataFetcher(caller bind.ContractCaller, chainID uint64, cache *MetadataCache) *MetadataFetcher {
+	if cache == nil {
+		cache = NewMetadataCache()
+	}
+	return &MetadataFetcher{caller: caller, chainID: chainID, cache: cache, DefaultDecimals: DefaultDecimals}
+}
+
+// Fetch 读取代币元数据，命中缓存时不发起请求
+// 某个方法回滚或返回值无法解码时记录为 Quirks，网络或节点错误（见 IsRevert）以及地址上没有代码时返回错误
+func (f *MetadataFetcher) Fetch(ctx context.Context, address common.Address) (Metadata, error) {
+	if md, ok := f.cache.Get(f.chainID, address); ok {
+		return md, nil
+	}
+	md, err := fetchMetadata(ctx, f.caller, address, f.DefaultDecimals)
+	if err != nil {
+		return Metadata{}, err
+	}
+	md.ChainID = f.chainID
+	f.cache.Put(md)
+	return md, nil
+}
+
+func fetchMetadata(ctx context.Context, caller bind.ContractCaller, address common.Address, defaultDecimals uint8) (Metadata, error) {
+	code, err := caller.CodeAt(ctx, address, nil)
+	if err != nil {
+		return Metadata{}, err
+	}
+	if len(code) == 0 {
+		return Metadata{}, fmt.Errorf("%w: %s", ErrNotContract, address.Hex())
+	}
+	md := Metadata{Address: address}
+	call := func(selector []byte) ([]byte, error) {
+		output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: selector}, nil)
+		if err != nil && IsRevert(err) {
+			return nil, nil
+		}
+		return output, err
+	}
+
+	output, err := call(nameSelector)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("name: %w", err)
+	}
+	md.Name, md.Quirks = decodeText(output, md.Quirks, QuirkBytes32Name, QuirkNoName)
+	if output, err = call(symbolSelector); err != nil {
+		return Metadata{}, fmt.Errorf("symbol: %w", err)
+	}
+	md.Symbol, md.Quirks = decodeText(output, md.Quirks, QuirkBytes32Symbol, QuirkNoSymbol)
+	if output, err = call(decimalsSelector); err != nil {
+		return Metadata{}, fmt.Errorf("decimals: %w", err)
+	}
+	decimals, err := DecodeDecimals(output)
+	switch {
+	case err == nil:
+		md.Decimals = decimals
+	case len(output) == 0:
+		md.Decimals, md.Quirks = defaultDecimals, md.Quirks|QuirkNoDecimals
+	default:
+		md.Decimals, md.Quirks = defaultDecimals, md.Quirks|QuirkBadDecimals
+	}
+	return md, nil
+}
+
+func decodeText(output []byte, quirks, bytes32Quirk, missingQuirk Quirks) (string, Quirks) {
+	s, isBytes32, err := DecodeText(output)
+	switch {
+	case err != nil:
+		return "", quirks | missingQuirk
+	case isBytes32:
+		return s, quirks | bytes32Quirk
+	}
+	return s, quirks
+}
+
+// DecodeText 解码 name()/symbol() 的返回值：优先按 ABI string 解码，恰好 32 字节时按 bytes32 解码（去掉末尾的 0）
+func DecodeText(output []byte) (s string, isBytes32 bool, err error) {
+	if len(output) >= 64 {
+		values, err := abi.Arguments{{Type: stringType}}.Unpack(output)
+		if err == nil {
+			return values[0].(string), false, nil
+		}
+	}
+	if len(output) == 32 {
+		trimmed := bytes.TrimRight(output, "\x00")
+		if bytes.IndexByte(trimmed, 0) < 0 && utf8.Valid(trimmed) {
+			return string(trimmed), true, nil
+		}
+	}
+	if len(output) == 0 {
+		return "", false, errors.New("empty return data")
+	}
+	return "", false, fmt.Errorf("cannot decode %d bytes as string or bytes32", len(output))
+}
+
+// DecodeDecimals 解码 decimals() 的返回值，部分代币声明为 uint256，只要数值不超过 255 就接受
+func DecodeDecimals(output []byte) (uint8, error) {
+	if len(output) == 0 {
+		return 0, errors.New("empty return data")
+	}
+	if len(output) != 32 {
+		return 0, fmt.Errorf("unexpected return data of %d bytes", len(output))
+	}
+	value := new(big.Int).SetBytes(output)
+	if !value.IsUint64() || value.Uint64() > 255 {
+		return 0, fmt.Errorf("decimals %s out of range", value)
+	}
+	return uint8(value.Uint64()), nil
+}
+
+// revertErrorCode geth 等节点对 eth_call/eth_estimateGas 回滚返回的 JSON-RPC 错误码
+const revertErrorCode = 3
+
+// IsRevert 是否为合约执行回滚（而不是网络或节点错误），包括调用不存在的方法
+// 节点的所有 JSON-RPC 错误都实现了 rpc.DataError，不能据此判断；只认错误码 3 或 "execution reverted"，
+// "header not found"、"missing trie node"、服务商限流等错误都不是回滚
+func IsRevert(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == revertErrorCode {
+		return true
+	}
+	return strings.Contains(err.Error(), "execution reverted")
+}
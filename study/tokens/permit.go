@@ -0,0 +1,244 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/signer"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// PermitVersions 重建 EIP-712 域时依次尝试的版本号，OpenZeppelin 的 ERC20Permit 为 "1"，USDC 为 "2"
+var PermitVersions = []string{"1", "2"}
+
+var (
+	// ErrDomainMismatch 无法用代币名称、已知版本号、链 ID 和合约地址重建出链上的 DOMAIN_SEPARATOR
+	ErrDomainMismatch = errors.New("cannot reproduce token DOMAIN_SEPARATOR")
+	// ErrPermitSigner 签名恢复出的地址不是 owner
+	ErrPermitSigner = errors.New("permit not signed by owner")
+	// ErrPermitNonce 签名中的 nonce 与链上 nonces(owner) 不一致（已被使用或尚未轮到）
+	ErrPermitNonce = errors.New("permit nonce does not match")
+	// ErrPermitExpired 最新区块时间已超过 deadline
+	ErrPermitExpired = errors.New("permit expired")
+)
+
+var permitTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"Permit": {
+		{Name: "owner", Type: "address"},
+		{Name: "spender", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	},
+}
+
+// Permit 一条 EIP-2612 授权签名及其签署的全部字段
+type Permit struct {
+	Token    common.Address
+	Name     string // EIP-712 域中的代币名称
+	Version  string // EIP-712 域中的版本号
+	ChainID  *big.Int
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+	Sig      []byte // 65 字节 [R || S || V]，V 为 27 或 28
+}
+
+// TypedData 签名所用的 EIP-712 结构化数据
+func (p *Permit) TypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       permitTypes,
+		PrimaryType: "Permit",
+		Domain:      permitDomain(p.Name, p.Version, p.ChainID, p.Token),
+		Message: apitypes.TypedDataMessage{
+			"owner":    p.Owner.Hex(),
+			"spender":  p.Spender.Hex(),
+			"value":    p.Value,
+			"nonce":    p.Nonce,
+			"deadline": p.Deadline,
+		},
+	}
+}
+
+// VRS 把签名拆成 permit 方法需要的 v、r、s
+func (p *Permit) VRS() (v uint8, r, s [32]byte) {
+	copy(r[:], p.Sig[:32])
+	copy(s[:], p.Sig[32:64])
+	return p.Sig[crypto.RecoveryIDOffset], r, s
+}
+
+func permitDomain(name, version string, chainID *big.Int, verifyingContract common.Address) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              name,
+		Version:           version,
+		ChainId:           (*math.HexOrDecimal256)(chainID),
+		VerifyingContract: verifyingContract.Hex(),
+	}
+}
+
+// DomainSeparator 链上的 EIP-712 域分隔符
+func (t *Token) DomainSeparator(ctx context.Context) (common.Hash, error) {
+	separator, err := t.permit.DOMAINSEPARATOR(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("DOMAIN_SEPARATOR: %w", err)
+	}
+	return separator, nil
+}
+
+// PermitDomain 找出代币 EIP-712 域中的名称和版本号：名称取 name()，依次尝试 PermitVersions，直到算出的域分隔符与链上一致
+func (t *Token) PermitDomain(ctx context.Context, chainID *big.Int) (name, version string, err error) {
+	separator, err := t.DomainSeparator(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	for _, version := range PermitVersions {
+		data := apitypes.TypedData{Types: permitTypes, Domain: permitDomain(t.Name, version, chainID, t.Address)}
+		hash, err := data.HashStruct("EIP712Domain", data.Domain.Map())
+		if err != nil {
+			return "", "", err
+		}
+		if common.BytesToHash(hash) == separator {
+			return t.Name, version, nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: %s", ErrDomainMismatch, separator.Hex())
+}
+
+// Nonces owner 下一个 permit 签名应使用的 nonce
+func (t *Token) Nonces(ctx context.Context, owner common.Address) (*big.Int, error) {
+	return t.permit.Nonces(&bind.CallOpts{Context: ctx}, owner)
+}
+
+// SignPermit 读取域参数和 owner 当前的 nonce，用 s 签署授权 spender 使用 value 的 permit，签名在 deadline（Unix 秒）之后失效
+func (t *Token) SignPermit(ctx context.Context, s signer.Signer, chainID *big.Int, spender common.Address, value, deadline *big.Int) (*Permit, error) {
+	name, version, err := t.PermitDomain(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := t.Nonces(ctx, s.Address())
+	if err != nil {
+		return nil, fmt.Errorf("nonces: %w", err)
+	}
+	p := &Permit{
+		Token:    t.Address,
+		Name:     name,
+		Version:  version,
+		ChainID:  chainID,
+		Owner:    s.Address(),
+		Spender:  spender,
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: deadline,
+	}
+	if p.Sig, err = signer.SignTypedData(s, p.TypedData()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// VerifyPermit 在本地检查 permit 能否被合约接受：域分隔符一致、签名者为 owner、nonce 未被使用、未过期
+func (t *Token) VerifyPermit(ctx context.Context, p *Permit) error {
+	if p.Token != t.Address {
+		return fmt.Errorf("permit is for token %s, not %s", p.Token.Hex(), t.Address.Hex())
+	}
+	data := p.TypedData()
+	separator, err := t.DomainSeparator(ctx)
+	if err != nil {
+		return err
+	}
+	if hash, err := data.HashStruct("EIP712Domain", data.Domain.Map()); err != nil {
+		return err
+	} else if common.BytesToHash(hash) != separator {
+		return fmt.Errorf("%w: %s", ErrDomainMismatch, separator.Hex())
+	}
+	recovered, err := signer.RecoverTypedData(data, p.Sig)
+	if err != nil {
+		return err
+	}
+	if recovered != p.Owner {
+		return fmt.Errorf("%w: recovered %s", ErrPermitSigner, recovered.Hex())
+	}
+	nonce, err := t.Nonces(ctx, p.Owner)
+	if err != nil {
+		return fmt.Errorf("nonces: %w", err)
+	}
+	if nonce.Cmp(p.Nonce) != 0 {
+		return fmt.Errorf("%w: signed %s, current %s", ErrPermitNonce, p.Nonce, nonce)
+	}
+	head, err := t.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if p.Deadline.Cmp(new(big.Int).SetUint64(head.Time)) < 0 {
+		return fmt.Errorf("%w: deadline %s, latest block time %d", ErrPermitExpired, p.Deadline, head.Time)
+	}
+	return nil
+}
+
+// SubmitPermit 由 opts.From（任意账户，通常是中继）提交 permit，gas 由提交者支付
+func (t *Token) SubmitPermit(opts *bind.TransactOpts, p *Permit) (*types.Transaction, error) {
+	v, r, s := p.VRS()
+	return t.permit.Permit(opts, p.Owner, p.Spender, p.Value, p.Deadline, v, r, s)
+}
+
+// RelayTransfer 以 spender（opts.From）的身份提交 permit 并等待上链，再 transferFrom 把 owner 的 amount 转给 to
+// permit 可能已被他人抢先提交，此时 nonce 已变化但额度已生效，当前额度足够时直接跳过 permit
+func (t *Token) RelayTransfer(opts *bind.TransactOpts, backend bind.DeployBackend, p *Permit, to common.Address, amount *big.Int) ([]*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.From != p.Spender {
+		return nil, fmt.Errorf("permit spender is %s, relayer is %s", p.Spender.Hex(), opts.From.Hex())
+	}
+	var txs []*types.Transaction
+	allowance, err := t.Allowance(ctx, p.Owner, p.Spender)
+	if err != nil {
+		return nil, fmt.Errorf("allowance: %w", err)
+	}
+	if allowance.Cmp(amount) < 0 {
+		if err := t.VerifyPermit(ctx, p); err != nil {
+			return nil, err
+		}
+		tx, err := t.SubmitPermit(opts, p)
+		if err != nil {
+			return nil, fmt.Errorf("permit: %w", err)
+		}
+		txs = append(txs, tx)
+		receipt, err := bind.WaitMined(ctx, backend, tx)
+		if err != nil {
+			return txs, err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return txs, fmt.Errorf("permit: transaction %s reverted", tx.Hash().Hex())
+		}
+	}
+
+	data, err := t.abi.Pack("transferFrom", p.Owner, to, amount)
+	if err != nil {
+		return txs, err
+	}
+	if err := t.simulate(ctx, opts.From, data); err != nil {
+		return txs, fmt.Errorf("simulate transferFrom: %w", err)
+	}
+	tx, err := t.contract.TransferFrom(opts, p.Owner, to, amount)
+	if err != nil {
+		return txs, err
+	}
+	return append(txs, tx), nil
+}
@@ -0,0 +1,191 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	token "eth-client-study/study/erc20"
+	"eth-client-study/utils"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrInsufficientBalance 发送方代币余额不足
+	ErrInsufficientBalance = errors.New("insufficient token balance")
+	// ErrReturnedFalse 代币合约没有回滚，但返回了 false
+	ErrReturnedFalse = errors.New("token returned false")
+	// ErrNoTransferEvent 回执中没有与转账参数一致的 Transfer 事件
+	ErrNoTransferEvent = errors.New("no matching Transfer event in receipt")
+	// ErrOwnerMismatch 授权的 owner 与交易发送方不一致
+	ErrOwnerMismatch = errors.New("approval owner is not the transaction sender")
+)
+
+// Token 对 ERC20 合约的封装，按代币精度在十进制字符串与最小单位之间换算
+type Token struct {
+	Address  common.Address
+	Name     string
+	Symbol   string
+	Decimals uint8
+	Quirks   Quirks // 元数据的非标准之处，包含 QuirkNoDecimals 时 Decimals 为 DefaultDecimals，换算金额前应确认
+
+	contract *token.Erc20
+	permit   *token.Erc20Permit
+	abi      *abi.ABI
+	backend  bind.ContractBackend
+}
+
+// NewToken 读取代币元数据并创建 Token，元数据的读取规则见 MetadataFetcher
+func NewToken(ctx context.Context, address common.Address, backend bind.ContractBackend) (*Token, error) {
+	md, err := fetchMetadata(ctx, backend, address, DefaultDecimals)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenWithMetadata(address, backend, md)
+}
+
+// NewTokenWithMetadata 用已有的元数据（如 MetadataFetcher 缓存的结果）创建 Token，不发起请求
+func NewTokenWithMetadata(address common.Address, backend bind.ContractBackend, md Metadata) (*Token, error) {
+	contract, err := token.NewErc20(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	permit, err := token.NewErc20Permit(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := token.Erc20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		Address:  address,
+		Name:     md.Name,
+		Symbol:   md.Symbol,
+		Decimals: md.Decimals,
+		Quirks:   md.Quirks,
+		contract: contract,
+		permit:   permit,
+		abi:      parsed,
+		backend:  backend,
+	}, nil
+}
+
+// Contract 底层的 abigen 绑定
+func (t *Token) Contract() *token.Erc20 {
+	return t.contract
+}
+
+// ParseAmount 把 "1.5" 这样的十进制金额按代币精度换算为最小单位
+func (t *Token) ParseAmount(s string) (*big.Int, error) {
+	amount, err := utils.ParseUnits(s, t.Decimals)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("amount %q must be positive", s)
+	}
+	return amount, nil
+}
+
+// Format 把最小单位的金额格式化为十进制字符串并附上代币符号
+func (t *Token) Format(amount *big.Int) string {
+	if t.Symbol == "" {
+		return utils.FormatUnits(amount, t.Decimals)
+	}
+	return utils.FormatUnits(amount, t.Decimals) + " " + t.Symbol
+}
+
+// BalanceOf 查询 account 的最新余额
+func (t *Token) BalanceOf(ctx context.Context, account common.Address) (*big.Int, error) {
+	return t.contract.BalanceOf(&bind.CallOpts{Context: ctx}, account)
+}
+
+// SimulateTransfer 以 from 的身份 eth_call 一次 transfer，回滚或返回 false 时返回错误
+// USDT 等早期代币的 transfer 没有返回值，返回数据为空时视为成功
+func (t *Token) SimulateTransfer(ctx context.Context, from, to common.Address, amount *big.Int) error {
+	data, err := t.abi.Pack("transfer", to, amount)
+	if err != nil {
+		return err
+	}
+	return t.simulate(ctx, from, data)
+}
+
+func (t *Token) simulate(ctx context.Context, from common.Address, data []byte) error {
+	output, err := t.backend.CallContract(ctx, ethereum.CallMsg{From: from, To: &t.Address, Data: data}, nil)
+	if err != nil {
+		return err
+	}
+	return checkBool(output)
+}
+
+// checkBool 检查 transfer/approve 等方法的返回值：空表示没有返回值的非标准代币，否则必须是 ABI 编码的 true
+func checkBool(output []byte) error {
+	if len(output) == 0 {
+		return nil
+	}
+	if len(output) != 32 {
+		return fmt.Errorf("unexpected return data of %d bytes", len(output))
+	}
+	value := new(big.Int).SetBytes(output)
+	switch {
+	case value.Sign() == 0:
+		return ErrReturnedFalse
+	case value.Cmp(common.Big1) != 0:
+		return fmt.Errorf("unexpected return value %s", value)
+	}
+	return nil
+}
+
+// Transfer 检查余额并模拟执行后发送 transfer 交易，opts.From 为发送方
+func (t *Token) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	balance, err := t.BalanceOf(ctx, opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("balanceOf: %w", err)
+	}
+	if balance.Cmp(amount) < 0 {
+		return nil, fmt.Errorf("%w: have %s, want %s", ErrInsufficientBalance, t.Format(balance), t.Format(amount))
+	}
+	if err := t.SimulateTransfer(ctx, opts.From, to, amount); err != nil {
+		return nil, fmt.Errorf("simulate transfer: %w", err)
+	}
+	return t.contract.Transfer(opts, to, amount)
+}
+
+// VerifyTransfer 在回执中查找本代币 from -> to 的 Transfer 事件
+// 找到收款方一致但金额不同的事件时（如扣除手续费的代币）返回该事件和错误
+func (t *Token) VerifyTransfer(receipt *types.Receipt, from, to common.Address, amount *big.Int) (*token.Erc20Transfer, error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", receipt.TxHash.Hex())
+	}
+	var partial *token.Erc20Transfer
+	for _, log := range receipt.Logs {
+		if log.Address != t.Address || len(log.Topics) == 0 || log.Topics[0] != t.abi.Events["Transfer"].ID {
+			continue
+		}
+		event, err := t.contract.ParseTransfer(*log)
+		if err != nil {
+			continue
+		}
+		if event.From != from || event.To != to {
+			continue
+		}
+		if event.Value.Cmp(amount) == 0 {
+			return event, nil
+		}
+		partial = event
+	}
+	if partial != nil {
+		return partial, fmt.Errorf("%w: transferred %s, want %s", ErrNoTransferEvent, t.Format(partial.Value), t.Format(amount))
+	}
+	return nil, ErrNoTransferEvent
+}
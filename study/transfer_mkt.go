@@ -1,109 +1,98 @@
+// transfer_mkt.go - 转移 ERC20 代币
+// 按代币精度换算金额，发送前检查余额并模拟执行，上链后校验回执中的 Transfer 事件
+// 用法：
+//
+//	go run study/transfer_mkt.go -amount 1.5
+//	go run study/transfer_mkt.go -to bob -amount 100 -dry-run
 package main
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/tokens"
 	"eth-client-study/utils"
+	"flag"
 	"fmt"
 	"log"
-	"math/big"
 
-	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"golang.org/x/crypto/sha3"
 )
 
-// 转移20代币
 func main() {
+	tokenFlag := flag.String("token", "mkt", "ERC20 代币地址或地址簿名称")
+	toFlag := flag.String("to", "carol", "接收地址或地址簿名称")
+	amountFlag := flag.String("amount", "1", "转账金额，按代币精度解析，如 1.5")
+	dryRunFlag := flag.Bool("dry-run", false, "只检查余额并模拟执行，不发送交易")
+	flag.Parse()
 
 	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/xxxxxxxx")
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer client.Close()
+	ctx := context.Background()
+	book := addressbook.MustOpen(ctx, client)
+
 	//账户私钥
 	privateKey, err := crypto.HexToECDSA(utils.GetEnv("PRIVATE_KEY1"))
 	if err != nil {
 		log.Fatal("privateKey err:", err)
 	}
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		log.Fatal("获取链ID失败：", err)
 	}
-
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
 	if err != nil {
 		log.Fatal(err)
 	}
-	//接收地址
-	toAddress := common.HexToAddress("0xac787ff5df204282fc4a9216e2c5e5fc3d703574")
-	//合约地址
-	tokenAddress := common.HexToAddress("0x2f8C29909a2697E4E0449662302aAa1750f2cF98")
-
-	//转账签名
-	methodID := []byte("transfer(address,uint256)")
-	hash := sha3.NewLegacyKeccak256()
-	hash.Write(methodID)
-	//转账函数hash
-	transferFnSig := hash.Sum(nil)[:4]
-	fmt.Println("transferFnSig:", hexutil.Encode(transferFnSig)) // 0xa9059cbb
-	paddedAddress := common.LeftPadBytes(toAddress.Bytes(), 32)
-	fmt.Println("paddedAddress:", hexutil.Encode(paddedAddress)) //
-	amount := new(big.Int)
-	amount.SetString("1000000000000000000000000000", 10) // 十亿 tokens
-	paddedAmount := common.LeftPadBytes(amount.Bytes(), 32)
-	fmt.Println("paddedAmount:", hexutil.Encode(paddedAmount)) //
-	data := append(transferFnSig, paddedAddress...)
-	data = append(data, paddedAmount...)
+	opts.Context = ctx
+	toAddress := book.MustResolve("-to", *toFlag)
 
-	gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
-		From: fromAddress,
-		To:   &tokenAddress,
-		Data: data,
-	})
+	erc20, err := tokens.NewToken(ctx, book.MustResolve("-token", *tokenFlag), client)
 	if err != nil {
-		log.Fatal("Gas估算失败：", err)
+		log.Fatal("读取代币信息失败：", err)
 	}
-	fmt.Println("估算GasLimit：", gasLimit)
-
-	// 7. 获取推荐GasPrice（Legacy交易用固定GasPrice）
-	gasPrice, err := client.SuggestGasPrice(context.Background())
-	if err != nil {
-		log.Fatal("获取GasPrice失败：", err)
+	if erc20.Quirks.Has(tokens.QuirkNoDecimals) || erc20.Quirks.Has(tokens.QuirkBadDecimals) {
+		log.Fatalf("代币没有有效的 decimals()（%s），无法确定金额精度", erc20.Quirks)
 	}
-	fmt.Println("推荐GasPrice：", gasPrice.String(), "wei")
-	// 8. 构造交易
-	// 注意：To是代币合约地址，Value是0（ERC20转账不转ETH）
-	tx := types.NewTransaction(
-		nonce,         // 交易序号
-		tokenAddress,  // 目标地址（代币合约）
-		big.NewInt(0), // 转账ETH金额（ERC20转账设为0）
-		gasLimit+1000, // GasLimit（估算值+1000缓冲，避免Gas不足）
-		gasPrice,      // 固定GasPrice（Legacy交易）
-		data,          // 合约调用数据
-	)
-	// 9. 签名交易
-	chainID, err := client.NetworkID(context.Background())
+	amount, err := erc20.ParseAmount(*amountFlag)
 	if err != nil {
-		chainID = big.NewInt(11155111) // 降级使用硬编码链ID
-		log.Println("获取链ID失败：", err)
+		log.Fatal(err)
 	}
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
+	balance, err := erc20.BalanceOf(ctx, opts.From)
 	if err != nil {
-		log.Fatal("交易签名失败：", err)
+		log.Fatal(err)
 	}
+	fmt.Printf("代币：%s（精度 %d）\n", erc20.Symbol, erc20.Decimals)
+	fmt.Printf("发送方 %s 余额：%s\n", opts.From.Hex(), erc20.Format(balance))
+	fmt.Printf("转账 %s（%s 最小单位）到 %s\n", erc20.Format(amount), amount, toAddress.Hex())
 
-	// 10. 发送交易
-	err = client.SendTransaction(context.Background(), signedTx)
-	if err != nil {
-		log.Fatal("发送交易失败：", err)
+	if *dryRunFlag {
+		if balance.Cmp(amount) < 0 {
+			log.Fatal("余额不足")
+		}
+		if err := erc20.SimulateTransfer(ctx, opts.From, toAddress, amount); err != nil {
+			log.Fatal("模拟执行失败：", err)
+		}
+		fmt.Println("模拟执行成功")
+		return
 	}
 
-	fmt.Printf("交易发送成功！TxHash：%s\n", signedTx.Hash().Hex())
+	tx, err := erc20.Transfer(opts, toAddress, amount)
+	if err != nil {
+		log.Fatal("转账失败：", err)
+	}
+	fmt.Printf("交易发送成功！TxHash：%s\n", tx.Hash().Hex())
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	event, err := erc20.VerifyTransfer(receipt, opts.From, toAddress, amount)
+	if err != nil {
+		log.Fatal("校验 Transfer 事件失败：", err)
+	}
+	fmt.Printf("已上链：区块 %d，Transfer %s -> %s %s\n", receipt.BlockNumber, event.From.Hex(), event.To.Hex(), erc20.Format(event.Value))
 }
@@ -0,0 +1,131 @@
+package vanity
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrEmptyPattern 前缀和后缀都为空，任何地址都匹配
+	ErrEmptyPattern = errors.New("pattern has no prefix or suffix")
+	// ErrPatternHex 前缀或后缀中有非十六进制字符
+	ErrPatternHex = errors.New("pattern contains non-hex characters")
+	// ErrPatternLength 前缀和后缀合计超过 40 个字符
+	ErrPatternLength = errors.New("pattern is longer than an address")
+)
+
+// Pattern 地址的前缀和后缀（不含 0x）
+// 区分大小写时按 EIP-55 校验和后的地址匹配，每个字母 a-f 的大小写由地址哈希决定，难度翻倍
+type Pattern struct {
+	prefix, suffix string // 区分大小写时保留原样，否则为小写
+	lower          [2][]byte
+	caseSensitive  bool
+}
+
+// NewPattern 解析前缀和后缀，前缀可以带 0x
+func NewPattern(prefix, suffix string, caseSensitive bool) (Pattern, error) {
+	prefix = strings.TrimPrefix(strings.TrimSpace(prefix), "0x")
+	suffix = strings.TrimSpace(suffix)
+	if prefix == "" && suffix == "" {
+		return Pattern{}, ErrEmptyPattern
+	}
+	if len(prefix)+len(suffix) > 2*common.AddressLength {
+		return Pattern{}, fmt.Errorf("%w: %d characters", ErrPatternLength, len(prefix)+len(suffix))
+	}
+	for _, c := range prefix + suffix {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return Pattern{}, fmt.Errorf("%w: %q", ErrPatternHex, c)
+		}
+	}
+	if !caseSensitive {
+		prefix, suffix = strings.ToLower(prefix), strings.ToLower(suffix)
+	}
+	return Pattern{
+		prefix:        prefix,
+		suffix:        suffix,
+		lower:         [2][]byte{[]byte(strings.ToLower(prefix)), []byte(strings.ToLower(suffix))},
+		caseSensitive: caseSensitive,
+	}, nil
+}
+
+// String 以 0x<前缀>…<后缀> 的形式展示
+func (p Pattern) String() string {
+	s := "0x" + p.prefix + "…" + p.suffix
+	if p.caseSensitive {
+		s += "（区分大小写）"
+	}
+	return s
+}
+
+// Match 地址是否符合模式；先比较小写十六进制，只有命中时才计算校验和，避免每次尝试都多算一次哈希
+func (p Pattern) Match(address common.Address) bool {
+	var buf [2 * common.AddressLength]byte
+	hex.Encode(buf[:], address[:])
+	if !bytes.HasPrefix(buf[:], p.lower[0]) || !bytes.HasSuffix(buf[:], p.lower[1]) {
+		return false
+	}
+	if !p.caseSensitive {
+		return true
+	}
+	s := address.Hex()[2:]
+	return strings.HasPrefix(s, p.prefix) && strings.HasSuffix(s, p.suffix)
+}
+
+// Difficulty 平均每多少次尝试命中一次：每个十六进制字符 16 倍，区分大小写时每个字母再乘 2
+func (p Pattern) Difficulty() float64 {
+	chars := p.prefix + p.suffix
+	d := math.Pow(16, float64(len(chars)))
+	if p.caseSensitive {
+		letters := 0
+		for _, c := range strings.ToLower(chars) {
+			if 'a' <= c && c <= 'f' {
+				letters++
+			}
+		}
+		d *= math.Pow(2, float64(letters))
+	}
+	return d
+}
+
+// Probability 尝试 attempts 次后至少命中一次的概率
+func Probability(difficulty float64, attempts uint64) float64 {
+	return -math.Expm1(-float64(attempts) / difficulty)
+}
+
+// AttemptsFor 达到 probability 的命中概率所需的尝试次数
+func AttemptsFor(difficulty, probability float64) float64 {
+	return -difficulty * math.Log1p(-probability)
+}
+
+// Progress 搜索进度
+type Progress struct {
+	Attempts   uint64
+	Elapsed    time.Duration
+	Rate       float64 // 每秒尝试次数
+	Difficulty float64
+}
+
+// Probability 到目前为止至少命中一次的概率
+func (p Progress) Probability() float64 {
+	return Probability(p.Difficulty, p.Attempts)
+}
+
+// Remaining 按当前速度，累计命中概率达到 probability 还需要的时间，已达到时返回 0，超过约 292 年时返回 math.MaxInt64
+func (p Progress) Remaining(probability float64) time.Duration {
+	left := AttemptsFor(p.Difficulty, probability) - float64(p.Attempts)
+	if left <= 0 || p.Rate <= 0 {
+		return 0
+	}
+	d := left / p.Rate * float64(time.Second)
+	if d >= math.MaxInt64 {
+		return math.MaxInt64 // 约 292 年，超出时间范围
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,192 @@
+package vanity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// batchSize 每个 worker 每尝试这么多次才汇报一次计数并检查是否已取消
+const batchSize = 256
+
+// Options 搜索参数
+type Options struct {
+	Workers  int            // 并发数，0 表示 runtime.NumCPU()
+	Interval time.Duration  // 进度回调间隔，0 表示每秒一次
+	Progress func(Progress) // 进度回调，可为空
+}
+
+// worker 逐个生成候选地址，命中后由 secret 取出上一个候选对应的私钥或 salt
+type worker struct {
+	next   func() (common.Address, error)
+	secret func() any
+}
+
+// search 在多个 goroutine 中运行 newWorker 创建的 worker 直到命中模式或 ctx 被取消
+func search(ctx context.Context, p Pattern, opts Options, newWorker func() (worker, error)) (common.Address, any, Progress, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		address common.Address
+		secret  any
+		err     error
+	}
+	var (
+		attempts atomic.Uint64
+		found    = make(chan result, 1)
+		wg       sync.WaitGroup
+		start    = time.Now()
+	)
+	report := func() Progress {
+		elapsed := time.Since(start)
+		n := attempts.Load()
+		return Progress{Attempts: n, Elapsed: elapsed, Rate: float64(n) / elapsed.Seconds(), Difficulty: p.Difficulty()}
+	}
+	for w := 0; w < workers; w++ {
+		wk, err := newWorker()
+		if err != nil {
+			cancel()
+			wg.Wait()
+			return common.Address{}, nil, Progress{}, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				for i := 0; i < batchSize; i++ {
+					address, err := wk.next()
+					if err == nil && !p.Match(address) {
+						continue
+					}
+					attempts.Add(uint64(i + 1))
+					r := result{address: address, err: err}
+					if err == nil {
+						r.secret = wk.secret()
+					}
+					select {
+					case found <- r:
+					default:
+					}
+					cancel()
+					return
+				}
+				attempts.Add(batchSize)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case r := <-found:
+			cancel()
+			wg.Wait()
+			return r.address, r.secret, report(), r.err
+		case <-ctx.Done():
+			wg.Wait()
+			// 取消与命中可能同时发生，命中优先
+			select {
+			case r := <-found:
+				return r.address, r.secret, report(), r.err
+			default:
+			}
+			return common.Address{}, nil, report(), fmt.Errorf("search stopped after %d attempts: %w", attempts.Load(), context.Cause(ctx))
+		case <-ticker.C:
+			if opts.Progress != nil {
+				opts.Progress(report())
+			}
+		}
+	}
+}
+
+// KeyResult 找到的外部账户
+type KeyResult struct {
+	Key      *ecdsa.PrivateKey
+	Address  common.Address
+	Progress Progress
+}
+
+// FindKey 随机生成私钥直到地址符合模式
+func FindKey(ctx context.Context, p Pattern, opts Options) (*KeyResult, error) {
+	address, secret, progress, err := search(ctx, p, opts, func() (worker, error) {
+		var key *ecdsa.PrivateKey
+		return worker{
+			next: func() (common.Address, error) {
+				var err error
+				if key, err = crypto.GenerateKey(); err != nil {
+					return common.Address{}, err
+				}
+				return crypto.PubkeyToAddress(key.PublicKey), nil
+			},
+			secret: func() any { return key },
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &KeyResult{Key: secret.(*ecdsa.PrivateKey), Address: address, Progress: progress}, nil
+}
+
+// SaltResult 找到的 CREATE2 salt
+type SaltResult struct {
+	Salt     common.Hash
+	Address  common.Address
+	Progress Progress
+}
+
+// Create2Address CREATE2 部署的合约地址：keccak256(0xff ++ deployer ++ salt ++ keccak256(initCode))[12:]
+func Create2Address(deployer common.Address, salt, initCodeHash common.Hash) common.Address {
+	return crypto.CreateAddress2(deployer, salt, initCodeHash[:])
+}
+
+// FindSalt 搜索使 deployer 用 CREATE2 部署 initCodeHash 对应的合约时地址符合模式的 salt
+// 每个 worker 从随机的 salt 开始，逐次递增最后 8 字节，并复用同一个哈希状态
+func FindSalt(ctx context.Context, p Pattern, deployer common.Address, initCodeHash common.Hash, opts Options) (*SaltResult, error) {
+	address, secret, progress, err := search(ctx, p, opts, func() (worker, error) {
+		var buf [1 + common.AddressLength + 2*common.HashLength]byte
+		buf[0] = 0xff
+		copy(buf[1:], deployer[:])
+		salt := buf[1+common.AddressLength : 1+common.AddressLength+common.HashLength]
+		copy(buf[1+common.AddressLength+common.HashLength:], initCodeHash[:])
+		if _, err := rand.Read(salt); err != nil {
+			return worker{}, err
+		}
+		counter := binary.BigEndian.Uint64(salt[24:])
+		hasher := crypto.NewKeccakState()
+		var hash common.Hash
+		return worker{
+			next: func() (common.Address, error) {
+				counter++
+				binary.BigEndian.PutUint64(salt[24:], counter)
+				hasher.Reset()
+				hasher.Write(buf[:])
+				hasher.Read(hash[:])
+				return common.BytesToAddress(hash[12:]), nil
+			},
+			secret: func() any { return common.BytesToHash(salt) },
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SaltResult{Salt: secret.(common.Hash), Address: address, Progress: progress}, nil
+}
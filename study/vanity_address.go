@@ -0,0 +1,164 @@
+// vanity_address.go - 多核搜索指定前缀/后缀的靓号地址，或搜索使 CREATE2 合约地址符合模式的 salt
+// 启动时输出难度估算，搜索中定期输出速度、成功概率和预计剩余时间，按 Ctrl+C 停止
+// 用法：
+//
+//	go run study/vanity_address.go -prefix dead
+//	go run study/vanity_address.go -prefix CafE -case -estimate
+//	go run study/vanity_address.go -prefix 0000 -deployer 0x4e59b44847b379578588920cA78FbF26c0B4956C -bin study/Store_sol_Store.bin
+//	go run study/vanity_address.go -suffix beef -deployer 0x... -init-code-hash 0x...
+//	go run study/vanity_address.go -prefix 0000 -deployer multicall3 -chain-id 11155111 -init-code-hash 0x...
+package main
+
+import (
+	"context"
+	"errors"
+	"eth-client-study/study/addressbook"
+	"eth-client-study/study/vanity"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func main() {
+	prefixFlag := flag.String("prefix", "", "地址前缀（十六进制，可带 0x）")
+	suffixFlag := flag.String("suffix", "", "地址后缀（十六进制）")
+	caseFlag := flag.Bool("case", false, "按 EIP-55 校验和区分大小写匹配，每个字母难度翻倍")
+	workersFlag := flag.Int("workers", runtime.NumCPU(), "并发数")
+	timeoutFlag := flag.Duration("timeout", 0, "最长搜索时间，0 表示不限")
+	estimateFlag := flag.Bool("estimate", false, "只测速 5 秒并按测得的速度估算所需时间")
+	deployerFlag := flag.String("deployer", "", "CREATE2 部署者（工厂合约）地址或地址簿名称，设置后搜索 salt 而不是私钥")
+	chainFlag := flag.Uint64("chain-id", 11155111, "-deployer 的地址簿名称和校验和所属的链 ID")
+	binFlag := flag.String("bin", "", "CREATE2 模式下的合约创建代码文件（十六进制，含构造参数）")
+	hashFlag := flag.String("init-code-hash", "", "CREATE2 模式下的 keccak256(创建代码)，与 -bin 二选一")
+	flag.Parse()
+
+	pattern, err := vanity.NewPattern(*prefixFlag, *suffixFlag, *caseFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	create2 := *deployerFlag != ""
+	var deployer common.Address
+	var initCodeHash common.Hash
+	if create2 {
+		// 不连接节点，按 -chain-id 选择地址簿中的链
+		deployer = addressbook.MustOpenChain(*chainFlag).MustResolve("-deployer", *deployerFlag)
+		switch {
+		case *binFlag != "" && *hashFlag == "":
+			data, err := os.ReadFile(*binFlag)
+			if err != nil {
+				log.Fatal(err)
+			}
+			code, err := hexutil.Decode("0x" + strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+			if err != nil {
+				log.Fatalf("%s 不是十六进制的创建代码：%v", *binFlag, err)
+			}
+			initCodeHash = crypto.Keccak256Hash(code)
+		case *hashFlag != "" && *binFlag == "":
+			b, err := hexutil.Decode(*hashFlag)
+			if err != nil || len(b) != common.HashLength {
+				log.Fatalf("-init-code-hash 必须是 32 字节的十六进制：%s", *hashFlag)
+			}
+			initCodeHash = common.BytesToHash(b)
+		default:
+			log.Fatal("CREATE2 模式需要 -bin 或 -init-code-hash 之一")
+		}
+	}
+
+	difficulty := pattern.Difficulty()
+	fmt.Printf("模式：%s\n", pattern)
+	if create2 {
+		fmt.Printf("CREATE2 部署者：%s，initCodeHash：%s\n", deployer.Hex(), initCodeHash.Hex())
+	}
+	fmt.Printf("难度：平均 %.0f 次命中一次，50%% 概率需 %.0f 次，90%% 概率需 %.0f 次\n",
+		difficulty, vanity.AttemptsFor(difficulty, 0.5), vanity.AttemptsFor(difficulty, 0.9))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *estimateFlag {
+		*timeoutFlag = 5 * time.Second
+	}
+	if *timeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeoutFlag)
+		defer cancel()
+	}
+
+	var last vanity.Progress
+	opts := vanity.Options{
+		Workers:  *workersFlag,
+		Interval: 2 * time.Second,
+		Progress: func(p vanity.Progress) {
+			last = p
+			if *estimateFlag {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "已尝试 %d 次，%.0f 次/秒，已有 %.1f%% 的概率命中，", p.Attempts, p.Rate, 100*p.Probability())
+			target := 0.5
+			if p.Probability() >= target {
+				target = 0.9
+			}
+			left := (vanity.AttemptsFor(p.Difficulty, target) - float64(p.Attempts)) / p.Rate
+			fmt.Fprintf(os.Stderr, "%.0f%% 概率还需 %s\n", 100*target, seconds(max(left, 0)))
+		},
+	}
+
+	if create2 {
+		result, err := vanity.FindSalt(ctx, pattern, deployer, initCodeHash, opts)
+		if err != nil {
+			stopped(err, *estimateFlag, last)
+			return
+		}
+		if vanity.Create2Address(deployer, result.Salt, initCodeHash) != result.Address {
+			log.Fatal("CREATE2 地址校验失败")
+		}
+		fmt.Printf("找到 salt：%s（尝试 %d 次，用时 %s）\n", result.Salt.Hex(), result.Progress.Attempts, result.Progress.Elapsed.Round(time.Millisecond))
+		fmt.Println("合约地址：", result.Address.Hex())
+		return
+	}
+	result, err := vanity.FindKey(ctx, pattern, opts)
+	if err != nil {
+		stopped(err, *estimateFlag, last)
+		return
+	}
+	fmt.Printf("找到地址：%s（尝试 %d 次，用时 %s）\n", result.Address.Hex(), result.Progress.Attempts, result.Progress.Elapsed.Round(time.Millisecond))
+	// 私钥只输出到终端，请立即妥善保存，不要写入仓库
+	fmt.Println("私钥：", hexutil.Encode(crypto.FromECDSA(result.Key))[2:])
+}
+
+// stopped 处理超时或 Ctrl+C：-estimate 时按测得的速度输出预计时间，否则报告未找到
+func stopped(err error, estimate bool, last vanity.Progress) {
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+	if last.Rate == 0 {
+		log.Fatal("未找到：", err)
+	}
+	if estimate {
+		fmt.Printf("速度：%.0f 次/秒\n", last.Rate)
+	} else {
+		fmt.Printf("未找到：已尝试 %d 次，%.0f 次/秒\n", last.Attempts, last.Rate)
+	}
+	fmt.Printf("预计时间：平均 %s，50%% 概率 %s，90%% 概率 %s\n",
+		seconds(last.Difficulty/last.Rate),
+		seconds(vanity.AttemptsFor(last.Difficulty, 0.5)/last.Rate),
+		seconds(vanity.AttemptsFor(last.Difficulty, 0.9)/last.Rate))
+}
+
+// seconds 把秒数格式化为可读的时长，超过 100 年时只输出年数
+func seconds(s float64) string {
+	const year = 365 * 24 * 3600
+	if s > 100*year {
+		return fmt.Sprintf("%.3g 年", s/year)
+	}
+	return time.Duration(s * float64(time.Second)).Round(time.Second).String()
+}
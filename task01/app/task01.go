@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"crypto/ecdsa"
+	"eth-client-study/study/create2"
 	"eth-client-study/task01/counter"
 	"eth-client-study/utils"
 	"fmt"
@@ -17,6 +18,9 @@ import (
 )
 
 type Task01 struct {
+	// Create2Salt 非空时 DeployCounterContract 通过确定性部署代理用 CREATE2 部署 Counter，
+	// 地址只取决于 salt（32 字节十六进制或任意字符串，见 create2.ParseSalt）和合约代码，与部署账户的 nonce 无关
+	Create2Salt string
 }
 
 // 转账eth
@@ -59,7 +63,7 @@ func (t *Task01) TransferEth() {
 	}
 	fmt.Println("gasPrice:", gasPrice.String())
 	//收款地址
-	toAddress := common.HexToAddress(utils.GetEnv("ACCOUNT_ADDRESS2"))
+	toAddress := utils.GetEnvAddress("ACCOUNT_ADDRESS2")
 	amount := big.NewInt(664000000000000000) //1 eth
 	//构建交易
 	tx := types.NewTransaction(nonce, toAddress, amount, gasLimit, gasPrice, nil)
@@ -153,21 +157,32 @@ func (t *Task01) DeployCounterContract() {
 		fmt.Println("获取transactor失败", err)
 		return
 	}
-	opts.Nonce = big.NewInt(int64(nonce))
-	opts.Value = big.NewInt(0)
-	opts.GasLimit = uint64(3000000)
-	opts.GasPrice = gasPrice
-	opts.Context = context.Background()
-	//部署合约
-	address, transaction, counterContract, err := counter.DeployCounter(opts, client)
-	if err != nil {
-		fmt.Println("部署合约失败", err)
-		return
-	}
-	fmt.Println("部署成功--合约地址:", address.Hex(), "交易Hash:", transaction.Hash().Hex())
+	var counterContract *counter.Counter
+	if t.Create2Salt != "" {
+		// CREATE2 部署可能先发送补足代理部署费用和部署代理的交易，nonce 和 gas 交给 bind 自动填写
+		opts.Context = context.Background()
+		if counterContract, err = deployCounterByCreate2(client, opts, t.Create2Salt); err != nil {
+			fmt.Println("CREATE2部署合约失败", err)
+			return
+		}
+	} else {
+		opts.Nonce = big.NewInt(int64(nonce))
+		opts.Value = big.NewInt(0)
+		opts.GasLimit = uint64(3000000)
+		opts.GasPrice = gasPrice
+		opts.Context = context.Background()
+		//部署合约
+		address, transaction, instance, err := counter.DeployCounter(opts, client)
+		if err != nil {
+			fmt.Println("部署合约失败", err)
+			return
+		}
+		fmt.Println("部署成功--合约地址:", address.Hex(), "交易Hash:", transaction.Hash().Hex())
 
-	// 等待部署交易确认
-	waitForTransaction(client, transaction.Hash())
+		// 等待部署交易确认
+		waitForTransaction(client, transaction.Hash())
+		counterContract = instance
+	}
 
 	count, _ := counterContract.GetCount(&bind.CallOpts{})
 	fmt.Println("初始化count:", count)
@@ -182,7 +197,7 @@ func (t *Task01) DeployCounterContract() {
 	gasPrice, _ = client.SuggestGasPrice(context.Background())
 	transactOpts.GasPrice = gasPrice
 	//调用合约Increment方法
-	transaction, err = counterContract.Increment(transactOpts)
+	transaction, err := counterContract.Increment(transactOpts)
 	if err != nil {
 		fmt.Println("调用合约失败", err)
 		return
@@ -212,6 +227,39 @@ func (t *Task01) DeployCounterContract() {
 
 }
 
+// deployCounterByCreate2 通过确定性部署代理部署 Counter，链上还没有代理时先用预签名交易部署代理
+// 同一 salt 的 Counter 已经部署过时不发送交易，直接使用已有的合约
+func deployCounterByCreate2(client *ethclient.Client, opts *bind.TransactOpts, saltText string) (*counter.Counter, error) {
+	salt, err := create2.ParseSalt(saltText)
+	if err != nil {
+		return nil, err
+	}
+	initCode, err := create2.InitCode(counter.CounterMetaData)
+	if err != nil {
+		return nil, err
+	}
+	deployer := create2.NewDeployer(client)
+	fmt.Println("代理合约:", deployer.Factory.Hex(), "salt:", salt.Hex())
+	fmt.Println("预先计算的合约地址:", deployer.Predict(salt, initCode).Hex())
+	deployed, err := deployer.EnsureFactory(opts)
+	if err != nil {
+		return nil, fmt.Errorf("部署代理合约: %w", err)
+	}
+	if deployed {
+		fmt.Println("链上没有代理合约，已用预签名交易部署")
+	}
+	deployment, err := deployer.Deploy(opts, salt, initCode)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Existing {
+		fmt.Println("合约已存在，跳过部署--合约地址:", deployment.Address.Hex())
+	} else {
+		fmt.Println("部署成功--合约地址:", deployment.Address.Hex(), "交易Hash:", deployment.Tx.Hash().Hex())
+	}
+	return counter.NewCounter(deployment.Address, client)
+}
+
 // waitForTransaction 等待交易被确认
 func waitForTransaction(client *ethclient.Client, txHash common.Hash) {
 	fmt.Printf("等待交易 %s 被确认...\n", txHash.Hex())
@@ -1,9 +1,15 @@
 package main
 
-import "eth-client-study/task01/app"
+import (
+	"eth-client-study/task01/app"
+	"flag"
+)
 
 func main() {
-	task01 := app.Task01{}
+	create2Salt := flag.String("create2-salt", "", "非空时通过确定性部署代理用 CREATE2 部署 Counter，地址只取决于该 salt")
+	flag.Parse()
+
+	task01 := app.Task01{Create2Salt: *create2Salt}
 	task01.QueryBlockInfo()
 	task01.TransferEth()
 	task01.DeployCounterContract()
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrAddressPrefix 地址没有以 0x 开头
+	ErrAddressPrefix = errors.New("address must start with 0x")
+	// ErrAddressLength 地址不是 40 个十六进制字符（20 字节）
+	ErrAddressLength = errors.New("address must be 40 hex characters")
+	// ErrAddressHex 地址中有非十六进制字符
+	ErrAddressHex = errors.New("address contains non-hex characters")
+	// ErrAddressChecksum 地址大小写混合但不符合校验和，通常是抄错了字符
+	ErrAddressChecksum = errors.New("address checksum mismatch")
+)
+
+// EIP1191ChainIDs 使用 EIP-1191 链相关校验和的链（RSK 主网和测试网），其他链使用 EIP-55
+var EIP1191ChainIDs = map[uint64]bool{30: true, 31: true}
+
+// ChecksumAddress 按 chainID 对应的规则输出带校验和的地址：EIP1191ChainIDs 中的链使用 EIP-1191，其余（包括 0）使用 EIP-55
+func ChecksumAddress(address common.Address, chainID uint64) string {
+	if !EIP1191ChainIDs[chainID] {
+		return address.Hex()
+	}
+	lower := strings.ToLower(address.Hex()[2:])
+	hash := crypto.Keccak256([]byte(strconv.FormatUint(chainID, 10) + "0x" + lower))
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// HasChecksum 地址是否大小写混合（即带有校验和）；全小写或全大写的地址无法发现抄错的字符
+func HasChecksum(s string) bool {
+	body := strings.TrimPrefix(strings.TrimSpace(s), "0x")
+	return body != strings.ToLower(body) && body != strings.ToUpper(body)
+}
+
+// ParseAddress 严格解析 EIP-55 地址，见 ParseAddressForChain
+func ParseAddress(s string) (common.Address, error) {
+	return ParseAddressForChain(s, 0)
+}
+
+// ParseAddressForChain 严格解析地址：必须以 0x 开头、恰好 40 个十六进制字符，大小写混合时必须符合 chainID 对应的校验和
+// 与 common.HexToAddress 不同，长度不对或含有非十六进制字符时返回错误，而不是截断或补零成另一个地址；
+// 全小写或全大写的地址没有校验和，可以解析，调用方应提示用户核对（见 HasChecksum）
+func ParseAddressForChain(s string, chainID uint64) (common.Address, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "0x") {
+		return common.Address{}, fmt.Errorf("%w: %q", ErrAddressPrefix, s)
+	}
+	body := s[2:]
+	if len(body) != 2*common.AddressLength {
+		return common.Address{}, fmt.Errorf("%w: %q has %d", ErrAddressLength, s, len(body))
+	}
+	for _, c := range body {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return common.Address{}, fmt.Errorf("%w: %q", ErrAddressHex, s)
+		}
+	}
+	address := common.HexToAddress(s)
+	if HasChecksum(s) {
+		if want := ChecksumAddress(address, chainID); s != want {
+			return common.Address{}, fmt.Errorf("%w: %s, want %s", ErrAddressChecksum, s, want)
+		}
+	}
+	return address, nil
+}
+
+// MustAddress 供命令行脚本解析 flag、参数和配置中的地址：格式或校验和错误时退出，没有校验和时打印警告
+// name 为输入的来源，如 "-to" 或 "ACCOUNT_ADDRESS2"
+func MustAddress(name, s string) common.Address {
+	address, err := ParseAddress(s)
+	if err != nil {
+		log.Fatalf("%s 不是有效的地址：%v", name, err)
+	}
+	if !HasChecksum(s) {
+		log.Printf("警告：%s 的地址 %s 没有校验和，请核对是否为 %s", name, strings.TrimSpace(s), address.Hex())
+	}
+	return address
+}
+
+// GetEnvAddress 读取 .env 中的地址配置，规则同 MustAddress
+func GetEnvAddress(key string) common.Address {
+	return MustAddress(key, GetEnv(key))
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatUnits 把最小单位的整数金额按精度格式化为十进制字符串
+// 例如 FormatUnits(1500000000000000000, 18) 返回 "1.5"
+func FormatUnits(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+	negative := amount.Sign() < 0
+	digits := new(big.Int).Abs(amount).String()
+	if decimals > 0 {
+		if len(digits) <= int(decimals) {
+			digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+		}
+		point := len(digits) - int(decimals)
+		digits = strings.TrimRight(digits[:point]+"."+digits[point:], "0")
+		digits = strings.TrimSuffix(digits, ".")
+	}
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// ParseUnits 把十进制字符串按精度转换为最小单位的整数金额，是 FormatUnits 的逆操作
+// 例如 ParseUnits("1.5", 18) 返回 1500000000000000000；小数位数超过精度时返回错误而不是截断
+func ParseUnits(value string, decimals uint8) (*big.Int, error) {
+	s := strings.TrimSpace(value)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	integer, fraction, _ := strings.Cut(s, ".")
+	if integer == "" && fraction == "" {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("amount %q has more than %d decimal places", value, decimals)
+	}
+	digits := integer + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid amount %q", value)
+		}
+	}
+	amount, _ := new(big.Int).SetString(digits, 10)
+	if negative {
+		amount.Neg(amount)
+	}
+	return amount, nil
+}
@@ -19,3 +19,12 @@ func GetEnv(key string) string {
 	}
 	return value
 }
+
+// GetEnvOrDefault 读取可选配置，.env 文件或该键不存在时返回默认值
+func GetEnvOrDefault(key, defaultValue string) string {
+	_ = godotenv.Load(".env")
+	if value, ok := os.LookupEnv(key); ok && len(value) > 0 {
+		return value
+	}
+	return defaultValue
+}